@@ -0,0 +1,284 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+)
+
+// Errors
+var (
+	ErrBiddingDisabled = errors.New("Bidding on lots is currently disabled")
+	ErrLotCleared      = errors.New("The lot has cleared")
+)
+
+// An offline model of a lot's price curve & bids
+type LotModel struct {
+	StartBlock     uint64   `json:"startBlock"`
+	EndBlock       uint64   `json:"endBlock"`
+	StartPrice     *big.Int `json:"startPrice"`
+	ReservePrice   *big.Int `json:"reservePrice"`
+	TotalRPLAmount *big.Int `json:"totalRplAmount"`
+	TotalBidAmount *big.Int `json:"totalBidAmount"`
+}
+
+// A point on a lot's price curve
+type LotPricePoint struct {
+	Block uint64   `json:"block"`
+	Price *big.Int `json:"price"`
+}
+
+// The simulated result of a bid on a lot
+type BidSimulation struct {
+	Block          uint64   `json:"block"`
+	BidAmount      *big.Int `json:"bidAmount"`
+	AcceptedAmount *big.Int `json:"acceptedAmount"`
+	RefundAmount   *big.Int `json:"refundAmount"`
+	PriceAtBlock   *big.Int `json:"priceAtBlock"`
+	ClearingPrice  *big.Int `json:"clearingPrice"`
+	RPLAmount      *big.Int `json:"rplAmount"`
+	MinRPLAmount   *big.Int `json:"minRplAmount"`
+	ClearsLot      bool     `json:"clearsLot"`
+}
+
+// A strategy to bid on a lot once its price falls to a target
+type BidStrategy struct {
+	LotIndex    uint64   `json:"lotIndex"`
+	TargetPrice *big.Int `json:"targetPrice"`
+	BidAmount   *big.Int `json:"bidAmount"`
+}
+
+// Create a lot model from its details
+func NewLotModel(lot LotDetails) LotModel {
+	return LotModel{
+		StartBlock:     lot.StartBlock,
+		EndBlock:       lot.EndBlock,
+		StartPrice:     lot.StartPrice,
+		ReservePrice:   lot.ReservePrice,
+		TotalRPLAmount: lot.TotalRPLAmount,
+		TotalBidAmount: lot.TotalBidAmount,
+	}
+}
+
+// Get the lot price at a block
+// The price falls quadratically from the start price to the reserve price over the lot's block range
+func (m LotModel) PriceAtBlock(blockNumber uint64) *big.Int {
+	if blockNumber <= m.StartBlock {
+		return new(big.Int).Set(m.StartPrice)
+	}
+	if blockNumber >= m.EndBlock {
+		return new(big.Int).Set(m.ReservePrice)
+	}
+	calcBase := eth.EthToWei(1)
+	x := new(big.Int).SetUint64(blockNumber - m.StartBlock)
+	x.Mul(x, calcBase)
+	x.Div(x, new(big.Int).SetUint64(m.EndBlock-m.StartBlock))
+	discount := new(big.Int).Sub(m.StartPrice, m.ReservePrice)
+	discount.Mul(discount, x)
+	discount.Mul(discount, x)
+	discount.Div(discount, new(big.Int).Mul(calcBase, calcBase))
+	return discount.Sub(m.StartPrice, discount)
+}
+
+// Get the lot price implied by its total bids
+func (m LotModel) PriceByTotalBids() *big.Int {
+	if m.TotalRPLAmount.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	price := new(big.Int).Mul(eth.EthToWei(1), m.TotalBidAmount)
+	return price.Div(price, m.TotalRPLAmount)
+}
+
+// Get the current lot price at a block
+func (m LotModel) CurrentPrice(blockNumber uint64) *big.Int {
+	priceAtBlock := m.PriceAtBlock(blockNumber)
+	priceByTotalBids := m.PriceByTotalBids()
+	if priceByTotalBids.Cmp(priceAtBlock) > 0 {
+		return priceByTotalBids
+	}
+	return priceAtBlock
+}
+
+// Get whether the lot has cleared at a block
+func (m LotModel) IsCleared(blockNumber uint64) bool {
+	return blockNumber >= m.EndBlock || m.PriceByTotalBids().Cmp(m.PriceAtBlock(blockNumber)) >= 0
+}
+
+// Get the maximum ETH amount which can be bid on the lot at a block
+func (m LotModel) MaxBidAmount(blockNumber uint64) *big.Int {
+	calcBase := eth.EthToWei(1)
+	price := m.PriceAtBlock(blockNumber)
+	if price.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	remainingRpl := new(big.Int).Mul(calcBase, m.TotalBidAmount)
+	remainingRpl.Div(remainingRpl, price)
+	remainingRpl.Sub(m.TotalRPLAmount, remainingRpl)
+	if remainingRpl.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+	maxBid := remainingRpl.Mul(remainingRpl, price)
+	return maxBid.Div(maxBid, calcBase)
+}
+
+// Get the first block at which the lot price is at or below a target price
+// Returns false if the price never falls to the target
+func (m LotModel) BlockAtPrice(targetPrice *big.Int) (uint64, bool) {
+	if m.PriceAtBlock(m.EndBlock).Cmp(targetPrice) > 0 {
+		return 0, false
+	}
+	low, high := m.StartBlock, m.EndBlock
+	for low < high {
+		mid := low + (high-low)/2
+		if m.PriceAtBlock(mid).Cmp(targetPrice) <= 0 {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	return low, true
+}
+
+// Get the lot price curve sampled at block intervals
+func (m LotModel) PriceCurve(interval uint64) []LotPricePoint {
+	if interval == 0 {
+		interval = 1
+	}
+	points := []LotPricePoint{}
+	for block := m.StartBlock; block < m.EndBlock; block += interval {
+		points = append(points, LotPricePoint{Block: block, Price: m.PriceAtBlock(block)})
+	}
+	return append(points, LotPricePoint{Block: m.EndBlock, Price: m.PriceAtBlock(m.EndBlock)})
+}
+
+// Simulate a bid on the lot at a block, assuming no further bids are placed
+// The RPL amount is received at the final clearing price, and the minimum RPL amount if other bids raise it to the price at the bid block
+func (m LotModel) SimulateBid(bidAmount *big.Int, blockNumber uint64) BidSimulation {
+	calcBase := eth.EthToWei(1)
+	simulation := BidSimulation{
+		Block:          blockNumber,
+		BidAmount:      new(big.Int).Set(bidAmount),
+		AcceptedAmount: big.NewInt(0),
+		RefundAmount:   new(big.Int).Set(bidAmount),
+		PriceAtBlock:   m.PriceAtBlock(blockNumber),
+		ClearingPrice:  m.CurrentPrice(blockNumber),
+		RPLAmount:      big.NewInt(0),
+		MinRPLAmount:   big.NewInt(0),
+		ClearsLot:      m.IsCleared(blockNumber),
+	}
+	if simulation.ClearsLot {
+		return simulation
+	}
+
+	// Get accepted & refunded amounts
+	maxBid := m.MaxBidAmount(blockNumber)
+	if bidAmount.Cmp(maxBid) > 0 {
+		simulation.AcceptedAmount.Set(maxBid)
+	} else {
+		simulation.AcceptedAmount.Set(bidAmount)
+	}
+	simulation.RefundAmount.Sub(bidAmount, simulation.AcceptedAmount)
+
+	// Get the clearing price after the bid
+	// The lot clears when the falling price meets the price by total bids, or at the reserve price
+	bidModel := m
+	bidModel.TotalBidAmount = new(big.Int).Add(m.TotalBidAmount, simulation.AcceptedAmount)
+	simulation.ClearingPrice = bidModel.PriceByTotalBids()
+	if simulation.ClearingPrice.Cmp(m.ReservePrice) < 0 {
+		simulation.ClearingPrice.Set(m.ReservePrice)
+	}
+	simulation.ClearsLot = bidModel.IsCleared(blockNumber)
+
+	// Get the RPL amounts
+	if simulation.ClearingPrice.Sign() > 0 {
+		simulation.RPLAmount.Mul(calcBase, simulation.AcceptedAmount)
+		simulation.RPLAmount.Div(simulation.RPLAmount, simulation.ClearingPrice)
+	}
+	if simulation.PriceAtBlock.Sign() > 0 {
+		simulation.MinRPLAmount.Mul(calcBase, simulation.AcceptedAmount)
+		simulation.MinRPLAmount.Div(simulation.MinRPLAmount, simulation.PriceAtBlock)
+	}
+
+	// Return
+	return simulation
+
+}
+
+// Get whether the strategy should bid on the lot at a block
+func (s BidStrategy) ShouldBid(model LotModel, blockNumber uint64) bool {
+	return !model.IsCleared(blockNumber) && model.PriceAtBlock(blockNumber).Cmp(s.TargetPrice) <= 0 && model.MaxBidAmount(blockNumber).Sign() > 0
+}
+
+// Place the strategy's bid if the lot price has fallen to the target
+// The bid is capped to the lot's remaining capacity; returns nil if the price is still above the target
+func PlaceBidAtTarget(rp *rocketpool.RocketPool, strategy BidStrategy, opts *bind.TransactOpts) (*common.Hash, error) {
+
+	// Check bidding is enabled
+	bidOnLotEnabled, err := protocol.GetBidOnLotEnabled(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !bidOnLotEnabled {
+		return nil, ErrBiddingDisabled
+	}
+
+	// Get the lot & current block
+	lot, err := GetLotDetails(rp, strategy.LotIndex, nil)
+	if err != nil {
+		return nil, err
+	}
+	blockNumber, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("Could not get current block: %w", err)
+	}
+
+	// Check the lot price
+	model := NewLotModel(lot)
+	if lot.Cleared || model.IsCleared(blockNumber) {
+		return nil, ErrLotCleared
+	}
+	if !strategy.ShouldBid(model, blockNumber) {
+		return nil, nil
+	}
+
+	// Place bid
+	simulation := model.SimulateBid(strategy.BidAmount, blockNumber)
+	bidOpts := *opts
+	bidOpts.Value = simulation.AcceptedAmount
+	hash, err := PlaceBid(rp, strategy.LotIndex, &bidOpts)
+	if err != nil {
+		return nil, err
+	}
+	return &hash, nil
+
+}
+
+// Poll the lot at an interval and place the strategy's bid once its price falls to the target
+// Waits while bidding is disabled, and returns when the bid is placed, the lot clears, or the context is cancelled
+func WatchAndBid(ctx context.Context, rp *rocketpool.RocketPool, strategy BidStrategy, interval time.Duration, opts *bind.TransactOpts) (common.Hash, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		hash, err := PlaceBidAtTarget(rp, strategy, opts)
+		if err != nil && !errors.Is(err, ErrBiddingDisabled) {
+			return common.Hash{}, err
+		}
+		if hash != nil {
+			return *hash, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return common.Hash{}, ctx.Err()
+		}
+	}
+}
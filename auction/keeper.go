@@ -0,0 +1,215 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/PatriceVignola/rocketpool-go/network"
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+)
+
+// Auction state used to plan keeper actions
+type KeeperData struct {
+	Lots                []LotDetails `json:"lots"`
+	RemainingRPLBalance *big.Int     `json:"remainingRplBalance"`
+	RPLPrice            *big.Int     `json:"rplPrice"`
+	LotMinimumEthValue  *big.Int     `json:"lotMinimumEthValue"`
+	CreateLotEnabled    bool         `json:"createLotEnabled"`
+	CurrentBlock        uint64       `json:"currentBlock"`
+}
+
+// Actions for the keeper to take on the auction
+type KeeperActions struct {
+	ClaimLots   []uint64 `json:"claimLots"`
+	RecoverLots []uint64 `json:"recoverLots"`
+	CreateLot   bool     `json:"createLot"`
+}
+
+// The result of a keeper run
+type KeeperResult struct {
+	Actions         KeeperActions          `json:"actions"`
+	ClaimHashes     map[uint64]common.Hash `json:"claimHashes"`
+	RecoverHashes   map[uint64]common.Hash `json:"recoverHashes"`
+	CreatedLot      bool                   `json:"createdLot"`
+	CreatedLotIndex uint64                 `json:"createdLotIndex"`
+	CreateLotHash   common.Hash            `json:"createLotHash"`
+	ClaimErrors     map[uint64]string      `json:"claimErrors"`
+	RecoverErrors   map[uint64]string      `json:"recoverErrors"`
+}
+
+// Get the auction state used to plan keeper actions for a bidder
+func GetKeeperData(rp *rocketpool.RocketPool, bidder common.Address, opts *bind.CallOpts) (KeeperData, error) {
+
+	// Data
+	var wg errgroup.Group
+	var lots []LotDetails
+	var remainingRplBalance *big.Int
+	var rplPrice *big.Int
+	var lotMinimumEthValue *big.Int
+	var createLotEnabled bool
+	var currentBlock uint64
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		lots, err = GetLotsWithBids(rp, bidder, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		remainingRplBalance, err = GetRemainingRPLBalance(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		rplPrice, err = network.GetRPLPrice(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		lotMinimumEthValue, err = protocol.GetLotMinimumEthValue(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		createLotEnabled, err = protocol.GetCreateLotEnabled(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		if opts != nil && opts.BlockNumber != nil {
+			currentBlock = opts.BlockNumber.Uint64()
+			return nil
+		}
+		var err error
+		currentBlock, err = rp.Client.BlockNumber(context.Background())
+		if err != nil {
+			return fmt.Errorf("Could not get current block: %w", err)
+		}
+		return nil
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return KeeperData{}, err
+	}
+
+	// Return
+	return KeeperData{
+		Lots:                lots,
+		RemainingRPLBalance: remainingRplBalance,
+		RPLPrice:            rplPrice,
+		LotMinimumEthValue:  lotMinimumEthValue,
+		CreateLotEnabled:    createLotEnabled,
+		CurrentBlock:        currentBlock,
+	}, nil
+
+}
+
+// Plan keeper actions from auction state
+// Claims cleared lots with bids, recovers unclaimed RPL from cleared lots which have reached their end block, and creates a lot if the remaining RPL balance meets the minimum lot value
+func PlanKeeperActions(data KeeperData) KeeperActions {
+	actions := KeeperActions{
+		ClaimLots:   []uint64{},
+		RecoverLots: []uint64{},
+	}
+	for _, lot := range data.Lots {
+		if !lot.Exists || !lot.Cleared {
+			continue
+		}
+		if lot.AddressBidAmount != nil && lot.AddressBidAmount.Sign() > 0 {
+			actions.ClaimLots = append(actions.ClaimLots, lot.Index)
+		}
+		if data.CurrentBlock >= lot.EndBlock && !lot.RPLRecovered && lot.RemainingRPLAmount != nil && lot.RemainingRPLAmount.Sign() > 0 {
+			actions.RecoverLots = append(actions.RecoverLots, lot.Index)
+		}
+	}
+	if data.CreateLotEnabled && data.RemainingRPLBalance != nil && data.RPLPrice != nil && data.LotMinimumEthValue != nil {
+		remainingEthValue := new(big.Int).Mul(data.RemainingRPLBalance, data.RPLPrice)
+		remainingEthValue.Div(remainingEthValue, eth.EthToWei(1))
+		actions.CreateLot = remainingEthValue.Sign() > 0 && remainingEthValue.Cmp(data.LotMinimumEthValue) >= 0
+	}
+	return actions
+}
+
+// Run the auction keeper once for the transactor
+// Claims the transactor's winnings from cleared lots, recovers unclaimed RPL from ended lots, and creates a new lot if possible
+// Failed claims & recoveries are recorded per lot without stopping the run
+func RunKeeper(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (KeeperResult, error) {
+
+	// Get keeper data & plan actions
+	data, err := GetKeeperData(rp, opts.From, nil)
+	if err != nil {
+		return KeeperResult{}, err
+	}
+	result := KeeperResult{
+		Actions:       PlanKeeperActions(data),
+		ClaimHashes:   make(map[uint64]common.Hash),
+		RecoverHashes: make(map[uint64]common.Hash),
+		ClaimErrors:   make(map[uint64]string),
+		RecoverErrors: make(map[uint64]string),
+	}
+
+	// Claim bids
+	for _, lotIndex := range result.Actions.ClaimLots {
+		hash, err := ClaimBid(rp, lotIndex, opts)
+		if err != nil {
+			result.ClaimErrors[lotIndex] = err.Error()
+			continue
+		}
+		result.ClaimHashes[lotIndex] = hash
+	}
+
+	// Recover unclaimed RPL
+	for _, lotIndex := range result.Actions.RecoverLots {
+		hash, err := RecoverUnclaimedRPL(rp, lotIndex, opts)
+		if err != nil {
+			result.RecoverErrors[lotIndex] = err.Error()
+			continue
+		}
+		result.RecoverHashes[lotIndex] = hash
+	}
+
+	// Create lot
+	if result.Actions.CreateLot {
+		lotIndex, hash, err := CreateLot(rp, opts)
+		if err != nil {
+			return result, err
+		}
+		result.CreatedLot = true
+		result.CreatedLotIndex = lotIndex
+		result.CreateLotHash = hash
+	}
+
+	// Return
+	return result, nil
+
+}
+
+// Run the auction keeper at an interval until the context is cancelled or a run fails
+// Each run's result, including any failed claims & recoveries, is passed to the callback if provided
+func WatchAndKeep(ctx context.Context, rp *rocketpool.RocketPool, interval time.Duration, opts *bind.TransactOpts, onResult func(KeeperResult)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		result, err := RunKeeper(rp, opts)
+		if err != nil {
+			return fmt.Errorf("Could not run auction keeper: %w", err)
+		}
+		if onResult != nil {
+			onResult(result)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -3,61 +3,244 @@ package dao
 import (
 	"encoding/hex"
 	"fmt"
+	"math/big"
+	"reflect"
 	"strings"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
 	strutils "github.com/PatriceVignola/rocketpool-go/utils/strings"
 )
 
-// Get the string representation of a proposal payload
-var getProposalPayloadStringLock sync.Mutex
+// A decoded proposal payload argument
+type ProposalPayloadArgument struct {
+	Name     string      `json:"name"`
+	Type     string      `json:"type"`
+	Value    interface{} `json:"value"`
+	ValueStr string      `json:"valueStr"`
+}
+
+// A decoded proposal payload
+type ProposalPayload struct {
+	Method      string                    `json:"method"`
+	Arguments   []ProposalPayloadArgument `json:"arguments"`
+	Description string                    `json:"description"`
+}
+
+// Get an argument value by name (without the leading underscore)
+func (p ProposalPayload) GetArgument(name string) (interface{}, bool) {
+	for _, arg := range p.Arguments {
+		if arg.Name == name {
+			return arg.Value, true
+		}
+	}
+	return nil, false
+}
 
+// Formats a uint setting's values for proposal descriptions (e.g. settings.FormatSettingValue)
+type SettingValueFormatter func(contractName, settingPath string, value *big.Int) string
+
+// Get the string representation of a proposal payload
 func GetProposalPayloadString(rp *rocketpool.RocketPool, daoName string, payload []byte) (string, error) {
+	method, args, err := unpackProposalPayload(rp, daoName, payload)
+	if err != nil {
+		return "", err
+	}
+	argStrs := make([]string, len(args))
+	for ai, arg := range args {
+		argStrs[ai] = formatPayloadValue(method.Inputs[ai].Type, arg)
+	}
+	return strutils.Sanitize(fmt.Sprintf("%s(%s)", method.RawName, strings.Join(argStrs, ","))), nil
+}
 
-	// Lock while getting proposal payload string
-	getProposalPayloadStringLock.Lock()
-	defer getProposalPayloadStringLock.Unlock()
+// Decode a proposal payload into its method, named arguments and a description of its action
+// Setting proposals are described with the setting's current value at the block in opts, formatted by formatSetting if set or as raw integers otherwise
+func DecodeProposalPayload(rp *rocketpool.RocketPool, daoName string, payload []byte, formatSetting SettingValueFormatter, opts *bind.CallOpts) (ProposalPayload, error) {
+
+	// Unpack payload
+	method, args, err := unpackProposalPayload(rp, daoName, payload)
+	if err != nil {
+		return ProposalPayload{}, err
+	}
+
+	// Get arguments
+	decoded := ProposalPayload{
+		Method:    method.RawName,
+		Arguments: make([]ProposalPayloadArgument, len(args)),
+	}
+	for ai, arg := range args {
+		input := method.Inputs[ai]
+		decoded.Arguments[ai] = ProposalPayloadArgument{
+			Name:     strings.TrimPrefix(input.Name, "_"),
+			Type:     input.Type.String(),
+			Value:    arg,
+			ValueStr: strutils.Sanitize(formatPayloadValue(input.Type, arg)),
+		}
+	}
+
+	// Get description
+	decoded.Description = strutils.Sanitize(describeProposalPayload(rp, method.RawName, args, formatSetting, opts))
+
+	// Return
+	return decoded, nil
+
+}
+
+// Unpack a proposal payload using its DAO contract ABI
+func unpackProposalPayload(rp *rocketpool.RocketPool, daoName string, payload []byte) (*abi.Method, []interface{}, error) {
 
 	// Get proposal DAO contract ABI
 	daoContractAbi, err := rp.GetABI(daoName)
 	if err != nil {
-		return "", fmt.Errorf("Could not get '%s' DAO contract ABI: %w", daoName, err)
+		return nil, nil, fmt.Errorf("Could not get '%s' DAO contract ABI: %w", daoName, err)
 	}
 
 	// Get proposal payload method
+	if len(payload) < 4 {
+		return nil, nil, fmt.Errorf("Could not get proposal payload method: payload is too short")
+	}
 	method, err := daoContractAbi.MethodById(payload)
 	if err != nil {
-		return "", fmt.Errorf("Could not get proposal payload method: %w", err)
+		return nil, nil, fmt.Errorf("Could not get proposal payload method: %w", err)
 	}
 
 	// Get proposal payload argument values
 	args, err := method.Inputs.UnpackValues(payload[4:])
 	if err != nil {
-		return "", fmt.Errorf("Could not get proposal payload arguments: %w", err)
+		return nil, nil, fmt.Errorf("Could not get proposal payload arguments: %w", err)
 	}
 
-	// Format argument values as strings
-	argStrs := []string{}
-	for ai, arg := range args {
-		switch method.Inputs[ai].Type.T {
-		case abi.AddressTy:
-			argStrs = append(argStrs, arg.(common.Address).Hex())
-		case abi.HashTy:
-			argStrs = append(argStrs, arg.(common.Hash).Hex())
-		case abi.FixedBytesTy:
-			fallthrough
-		case abi.BytesTy:
-			argStrs = append(argStrs, hex.EncodeToString(arg.([]byte)))
-		default:
-			argStrs = append(argStrs, fmt.Sprintf("%v", arg))
+	// Return
+	return method, args, nil
+
+}
+
+// Format a payload argument value as a string
+func formatPayloadValue(t abi.Type, value interface{}) string {
+	switch t.T {
+	case abi.AddressTy:
+		return value.(common.Address).Hex()
+	case abi.HashTy:
+		return value.(common.Hash).Hex()
+	case abi.BytesTy:
+		return hex.EncodeToString(value.([]byte))
+	case abi.FixedBytesTy:
+		v := reflect.ValueOf(value)
+		data := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(data), v)
+		return hex.EncodeToString(data)
+	case abi.SliceTy, abi.ArrayTy:
+		v := reflect.ValueOf(value)
+		elemStrs := make([]string, v.Len())
+		for ei := range elemStrs {
+			elemStrs[ei] = formatPayloadValue(*t.Elem, v.Index(ei).Interface())
 		}
+		return fmt.Sprintf("[%s]", strings.Join(elemStrs, ","))
+	case abi.TupleTy:
+		v := reflect.ValueOf(value)
+		elemStrs := make([]string, len(t.TupleElems))
+		for ei, elem := range t.TupleElems {
+			elemStrs[ei] = formatPayloadValue(*elem, v.Field(ei).Interface())
+		}
+		return fmt.Sprintf("(%s)", strings.Join(elemStrs, ","))
+	default:
+		return fmt.Sprintf("%v", value)
 	}
+}
 
-	// Build & return payload string
-	return strutils.Sanitize(fmt.Sprintf("%s(%s)", method.RawName, strings.Join(argStrs, ","))), nil
+// Describe the action of a DAO proposal payload
+func describeProposalPayload(rp *rocketpool.RocketPool, method string, args []interface{}, formatSetting SettingValueFormatter, opts *bind.CallOpts) string {
+	switch method {
+
+	case "proposalInvite":
+		return fmt.Sprintf("invite %s (%s) to join as a member with address %s", args[0].(string), args[1].(string), args[2].(common.Address).Hex())
+
+	case "proposalLeave":
+		return fmt.Sprintf("allow member %s to leave", args[0].(common.Address).Hex())
+
+	case "proposalReplace":
+		return fmt.Sprintf("replace member %s with %s (%s) with address %s", args[0].(common.Address).Hex(), args[1].(string), args[2].(string), args[3].(common.Address).Hex())
+
+	case "proposalKick":
+		return fmt.Sprintf("kick member %s with a fine of %.6f RPL", args[0].(common.Address).Hex(), eth.WeiToEth(args[1].(*big.Int)))
+
+	case "proposalSettingUint":
+		contractName, settingPath, value := args[0].(string), args[1].(string), args[2].(*big.Int)
+		if currentValue, err := getSettingUint(rp, contractName, settingPath, opts); err == nil {
+			return fmt.Sprintf("set %s.%s from %s to %s", contractName, settingPath, formatSettingValue(formatSetting, contractName, settingPath, currentValue), formatSettingValue(formatSetting, contractName, settingPath, value))
+		}
+		return fmt.Sprintf("set %s.%s to %s", contractName, settingPath, formatSettingValue(formatSetting, contractName, settingPath, value))
+
+	case "proposalSettingBool":
+		contractName, settingPath, value := args[0].(string), args[1].(string), args[2].(bool)
+		if currentValue, err := getSettingBool(rp, contractName, settingPath, opts); err == nil {
+			return fmt.Sprintf("set %s.%s from %t to %t", contractName, settingPath, currentValue, value)
+		}
+		return fmt.Sprintf("set %s.%s to %t", contractName, settingPath, value)
+
+	case "proposalSettingAddress":
+		return fmt.Sprintf("set %s.%s to %s", args[0].(string), args[1].(string), args[2].(common.Address).Hex())
+
+	case "proposalSettingRewardsClaimer":
+		return fmt.Sprintf("set the %s rewards claimer percentage to %.6f%%", args[0].(string), eth.WeiToEth(args[1].(*big.Int))*100)
+
+	case "proposalSpendTreasury":
+		return fmt.Sprintf("spend %.6f RPL from the treasury on invoice %s to %s", eth.WeiToEth(args[2].(*big.Int)), args[0].(string), args[1].(common.Address).Hex())
 
+	case "proposalUpgrade":
+		upgradeType, contractName, contractAbi, contractAddress := args[0].(string), args[1].(string), args[2].(string), args[3].(common.Address)
+		abiHash := crypto.Keccak256Hash([]byte(contractAbi)).Hex()
+		switch upgradeType {
+		case "upgradeContract":
+			return fmt.Sprintf("upgrade contract %s to address %s with ABI hash %s", contractName, contractAddress.Hex(), abiHash)
+		case "addContract":
+			return fmt.Sprintf("add contract %s at address %s with ABI hash %s", contractName, contractAddress.Hex(), abiHash)
+		case "upgradeABI":
+			return fmt.Sprintf("upgrade the ABI of contract %s to ABI hash %s", contractName, abiHash)
+		case "addABI":
+			return fmt.Sprintf("add ABI %s with ABI hash %s", contractName, abiHash)
+		}
+		return fmt.Sprintf("%s %s at address %s with ABI hash %s", upgradeType, contractName, contractAddress.Hex(), abiHash)
+
+	}
+	return "(unknown)"
+}
+
+// Format a uint setting value for a proposal description
+func formatSettingValue(formatSetting SettingValueFormatter, contractName, settingPath string, value *big.Int) string {
+	if formatSetting != nil {
+		return formatSetting(contractName, settingPath, value)
+	}
+	return value.String()
+}
+
+// Get the current value of a uint setting from its settings contract
+func getSettingUint(rp *rocketpool.RocketPool, contractName, settingPath string, opts *bind.CallOpts) (*big.Int, error) {
+	settingsContract, err := rp.GetContract(contractName)
+	if err != nil {
+		return nil, err
+	}
+	value := new(*big.Int)
+	if err := settingsContract.Call(opts, value, "getSettingUint", settingPath); err != nil {
+		return nil, fmt.Errorf("Could not get setting %s.%s: %w", contractName, settingPath, err)
+	}
+	return *value, nil
+}
+
+// Get the current value of a bool setting from its settings contract
+func getSettingBool(rp *rocketpool.RocketPool, contractName, settingPath string, opts *bind.CallOpts) (bool, error) {
+	settingsContract, err := rp.GetContract(contractName)
+	if err != nil {
+		return false, err
+	}
+	value := new(bool)
+	if err := settingsContract.Call(opts, value, "getSettingBool", settingPath); err != nil {
+		return false, fmt.Errorf("Could not get setting %s.%s: %w", contractName, settingPath, err)
+	}
+	return *value, nil
 }
@@ -0,0 +1,277 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	rptypes "github.com/PatriceVignola/rocketpool-go/types"
+)
+
+// Proposal deadline types
+type ProposalDeadlineType string
+
+const (
+	VotingOpensDeadline      ProposalDeadlineType = "votingOpens"
+	VotingClosesDeadline     ProposalDeadlineType = "votingCloses"
+	ExecutionExpiresDeadline ProposalDeadlineType = "executionExpires"
+)
+
+// Proposal event types
+type ProposalEventType string
+
+const (
+	ProposalNewEvent          ProposalEventType = "new"
+	ProposalStateChangedEvent ProposalEventType = "stateChanged"
+	ProposalReminderEvent     ProposalEventType = "reminder"
+)
+
+// An upcoming proposal deadline
+type ProposalTransition struct {
+	ProposalID uint64               `json:"proposalId"`
+	DAO        string               `json:"dao"`
+	Deadline   ProposalDeadlineType `json:"deadline"`
+	Time       uint64               `json:"time"`
+}
+
+// A proposal lifecycle event
+type ProposalEvent struct {
+	Type          ProposalEventType     `json:"type"`
+	ProposalID    uint64                `json:"proposalId"`
+	DAO           string                `json:"dao"`
+	Message       string                `json:"message"`
+	OldState      rptypes.ProposalState `json:"oldState"`
+	State         rptypes.ProposalState `json:"state"`
+	Deadline      ProposalDeadlineType  `json:"deadline,omitempty"`
+	DeadlineTime  uint64                `json:"deadlineTime,omitempty"`
+	TimeRemaining time.Duration         `json:"timeRemaining,omitempty"`
+	Time          uint64                `json:"time"`
+}
+
+// Tracks non-final proposals and emits state change & deadline reminder events
+type ProposalWatcher struct {
+	RocketPool      *rocketpool.RocketPool
+	DAO             string
+	ReminderOffsets []time.Duration
+
+	lock      sync.Mutex
+	proposals map[uint64]ProposalDetails
+	reminded  map[string]bool
+}
+
+// Create a new proposal watcher for a DAO (or all DAOs if daoName is empty)
+// A reminder is emitted for a deadline each time it comes within one of the reminder offsets (e.g. 24h and 1h before)
+func NewProposalWatcher(rp *rocketpool.RocketPool, daoName string, reminderOffsets []time.Duration) *ProposalWatcher {
+	return &ProposalWatcher{
+		RocketPool:      rp,
+		DAO:             daoName,
+		ReminderOffsets: reminderOffsets,
+		proposals:       make(map[uint64]ProposalDetails),
+		reminded:        make(map[string]bool),
+	}
+}
+
+// Get a proposal's upcoming deadlines based on its state
+func GetProposalTransitions(proposal ProposalDetails) []ProposalTransition {
+	deadlines := []ProposalDeadlineType{}
+	switch proposal.State {
+	case rptypes.Pending:
+		deadlines = []ProposalDeadlineType{VotingOpensDeadline, VotingClosesDeadline, ExecutionExpiresDeadline}
+	case rptypes.Active:
+		deadlines = []ProposalDeadlineType{VotingClosesDeadline, ExecutionExpiresDeadline}
+	case rptypes.Succeeded:
+		deadlines = []ProposalDeadlineType{ExecutionExpiresDeadline}
+	}
+	transitions := make([]ProposalTransition, len(deadlines))
+	for di, deadline := range deadlines {
+		transitions[di] = ProposalTransition{
+			ProposalID: proposal.ID,
+			DAO:        proposal.DAO,
+			Deadline:   deadline,
+			Time:       getProposalDeadlineTime(proposal, deadline),
+		}
+	}
+	return transitions
+}
+
+// Check whether a proposal state is final
+func IsFinalProposalState(state rptypes.ProposalState) bool {
+	switch state {
+	case rptypes.Pending, rptypes.Active, rptypes.Succeeded:
+		return false
+	}
+	return true
+}
+
+// Get the upcoming deadlines of all tracked proposals, sorted by time
+func (w *ProposalWatcher) GetUpcomingTransitions() []ProposalTransition {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	transitions := []ProposalTransition{}
+	for _, proposal := range w.proposals {
+		transitions = append(transitions, GetProposalTransitions(proposal)...)
+	}
+	sort.Slice(transitions, func(i, j int) bool {
+		if transitions[i].Time != transitions[j].Time {
+			return transitions[i].Time < transitions[j].Time
+		}
+		return transitions[i].ProposalID < transitions[j].ProposalID
+	})
+	return transitions
+}
+
+// Load the current proposals & block time and get the events since the last update
+func (w *ProposalWatcher) Update(opts *bind.CallOpts) ([]ProposalEvent, error) {
+
+	// Get call options block number
+	var blockNumber *big.Int
+	if opts != nil {
+		blockNumber = opts.BlockNumber
+	}
+
+	// Get block time
+	header, err := w.RocketPool.Client.HeaderByNumber(context.Background(), blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get block header: %w", err)
+	}
+
+	// Get proposals
+	var proposals []ProposalDetails
+	if w.DAO == "" {
+		proposals, err = GetProposals(w.RocketPool, opts)
+	} else {
+		proposals, err = GetDAOProposals(w.RocketPool, w.DAO, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Return
+	return w.UpdateProposals(proposals, header.Time), nil
+
+}
+
+// Update the tracked proposals at a block time and get the resulting events
+func (w *ProposalWatcher) UpdateProposals(proposals []ProposalDetails, currentTime uint64) []ProposalEvent {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	events := []ProposalEvent{}
+	for _, proposal := range proposals {
+
+		// Check for new proposals & state changes
+		previous, tracked := w.proposals[proposal.ID]
+		if !tracked {
+			if IsFinalProposalState(proposal.State) {
+				continue
+			}
+			events = append(events, newProposalEvent(ProposalNewEvent, proposal, proposal.State, currentTime))
+		} else if previous.State != proposal.State {
+			events = append(events, newProposalEvent(ProposalStateChangedEvent, proposal, previous.State, currentTime))
+		}
+
+		// Stop tracking final proposals
+		if IsFinalProposalState(proposal.State) {
+			delete(w.proposals, proposal.ID)
+			w.forgetReminders(proposal.ID)
+			continue
+		}
+		w.proposals[proposal.ID] = proposal
+
+		// Check for reminders
+		for _, transition := range GetProposalTransitions(proposal) {
+			if transition.Time <= currentTime {
+				continue
+			}
+			remaining := time.Duration(transition.Time-currentTime) * time.Second
+			remind := false
+			for _, offset := range w.ReminderOffsets {
+				key := getReminderKey(proposal.ID, transition.Deadline, offset)
+				if remaining <= offset && !w.reminded[key] {
+					w.reminded[key] = true
+					remind = true
+				}
+			}
+			if remind {
+				event := newProposalEvent(ProposalReminderEvent, proposal, proposal.State, currentTime)
+				event.Deadline = transition.Deadline
+				event.DeadlineTime = transition.Time
+				event.TimeRemaining = remaining
+				events = append(events, event)
+			}
+		}
+
+	}
+
+	return events
+}
+
+// Poll for proposal events at an interval until the context is cancelled or an update fails
+func (w *ProposalWatcher) Watch(ctx context.Context, interval time.Duration, events chan<- ProposalEvent) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		updateEvents, err := w.Update(nil)
+		if err != nil {
+			return err
+		}
+		for _, event := range updateEvents {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Create a proposal event
+func newProposalEvent(eventType ProposalEventType, proposal ProposalDetails, oldState rptypes.ProposalState, currentTime uint64) ProposalEvent {
+	return ProposalEvent{
+		Type:       eventType,
+		ProposalID: proposal.ID,
+		DAO:        proposal.DAO,
+		Message:    proposal.Message,
+		OldState:   oldState,
+		State:      proposal.State,
+		Time:       currentTime,
+	}
+}
+
+// Get the time of a proposal deadline
+func getProposalDeadlineTime(proposal ProposalDetails, deadline ProposalDeadlineType) uint64 {
+	switch deadline {
+	case VotingOpensDeadline:
+		return proposal.StartTime
+	case VotingClosesDeadline:
+		return proposal.EndTime
+	case ExecutionExpiresDeadline:
+		return proposal.ExpiryTime
+	}
+	return 0
+}
+
+// Get the key of a sent reminder
+func getReminderKey(proposalId uint64, deadline ProposalDeadlineType, offset time.Duration) string {
+	return fmt.Sprintf("%d.%s.%d", proposalId, deadline, offset)
+}
+
+// Forget the sent reminders for a proposal
+func (w *ProposalWatcher) forgetReminders(proposalId uint64) {
+	for _, transition := range []ProposalDeadlineType{VotingOpensDeadline, VotingClosesDeadline, ExecutionExpiresDeadline} {
+		for _, offset := range w.ReminderOffsets {
+			delete(w.reminded, getReminderKey(proposalId, transition, offset))
+		}
+	}
+}
@@ -40,6 +40,7 @@ type ProposalDetails struct {
 	IsExecuted      bool                  `json:"isExecuted"`
 	Payload         []byte                `json:"payload"`
 	PayloadStr      string                `json:"payloadStr"`
+	PayloadDecoded  *ProposalPayload      `json:"payloadDecoded,omitempty"`
 	State           rptypes.ProposalState `json:"state"`
 }
 
@@ -427,6 +428,28 @@ func GetProposalDetailsWithMember(rp *rocketpool.RocketPool, proposalId uint64,
 
 }
 
+// Get a proposal's details with its decoded payload
+// Setting values in the payload description are formatted by formatSetting if set (e.g. settings.FormatSettingValue)
+func GetProposalDetailsWithPayload(rp *rocketpool.RocketPool, proposalId uint64, formatSetting SettingValueFormatter, opts *bind.CallOpts) (ProposalDetails, error) {
+
+	// Get details
+	details, err := GetProposalDetails(rp, proposalId, opts)
+	if err != nil {
+		return ProposalDetails{}, err
+	}
+
+	// Decode payload
+	payloadDecoded, err := DecodeProposalPayload(rp, details.DAO, details.Payload, formatSetting, opts)
+	if err != nil {
+		payloadDecoded = ProposalPayload{Description: "(unknown)"}
+	}
+
+	// Return
+	details.PayloadDecoded = &payloadDecoded
+	return details, nil
+
+}
+
 // Get the proposal count
 func GetProposalCount(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint64, error) {
 	rocketDAOProposal, err := getRocketDAOProposal(rp)
@@ -12,6 +12,51 @@ import (
 	"github.com/PatriceVignola/rocketpool-go/utils/eth"
 )
 
+// Get whether bootstrap mode has been disabled
+// Settings can only be updated by proposal once it is disabled
+func GetBootstrapModeDisabled(rp *rocketpool.RocketPool, opts *bind.CallOpts) (bool, error) {
+	rocketDAOProtocol, err := getRocketDAOProtocol(rp)
+	if err != nil {
+		return false, err
+	}
+	disabled := new(bool)
+	if err := rocketDAOProtocol.Call(opts, disabled, "getBootstrapModeDisabled"); err != nil {
+		return false, fmt.Errorf("Could not get protocol DAO bootstrap mode disabled status: %w", err)
+	}
+	return *disabled, nil
+}
+
+// Get whether the protocol DAO is in bootstrap mode
+func GetBootstrapMode(rp *rocketpool.RocketPool, opts *bind.CallOpts) (bool, error) {
+	disabled, err := GetBootstrapModeDisabled(rp, opts)
+	if err != nil {
+		return false, err
+	}
+	return !disabled, nil
+}
+
+// Estimate the gas of BootstrapDisable
+func EstimateBootstrapDisableGas(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOProtocol, err := getRocketDAOProtocol(rp)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAOProtocol.GetTransactionGasInfo(opts, "bootstrapDisable", true)
+}
+
+// Permanently disable bootstrap mode
+func BootstrapDisable(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAOProtocol, err := getRocketDAOProtocol(rp)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hash, err := rocketDAOProtocol.Transact(opts, "bootstrapDisable", true)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not disable protocol DAO bootstrap mode: %w", err)
+	}
+	return hash, nil
+}
+
 // Estimate the gas of BootstrapBool
 func EstimateBootstrapBoolGas(rp *rocketpool.RocketPool, contractName, settingPath string, value bool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	rocketDAOProtocol, err := getRocketDAOProtocol(rp)
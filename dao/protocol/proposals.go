@@ -0,0 +1,82 @@
+package protocol
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/PatriceVignola/rocketpool-go/dao"
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+)
+
+// Config
+const ProposalsDAOName = "rocketDAOProtocolProposals"
+
+// Errors
+var (
+	ErrProposalsUnsupported = errors.New("Protocol DAO proposals are not supported by the deployed contracts; settings can only be updated in bootstrap mode")
+)
+
+// Get all protocol DAO proposal details
+func GetProposals(rp *rocketpool.RocketPool, opts *bind.CallOpts) ([]dao.ProposalDetails, error) {
+	return dao.GetDAOProposals(rp, ProposalsDAOName, opts)
+}
+
+// Get all protocol DAO proposal details with member data
+func GetProposalsWithMember(rp *rocketpool.RocketPool, memberAddress common.Address, opts *bind.CallOpts) ([]dao.ProposalDetails, error) {
+	return dao.GetDAOProposalsWithMember(rp, ProposalsDAOName, memberAddress, opts)
+}
+
+// Bootstrap a bool setting while in bootstrap mode
+// Returns ErrProposalsUnsupported once bootstrap mode is disabled, as the protocol DAO cannot submit proposals
+func BootstrapOrFailBool(rp *rocketpool.RocketPool, contractName, settingPath string, value bool, opts *bind.TransactOpts) (common.Hash, error) {
+	bootstrapMode, err := GetBootstrapMode(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if bootstrapMode {
+		return BootstrapBool(rp, contractName, settingPath, value, opts)
+	}
+	return common.Hash{}, ErrProposalsUnsupported
+}
+
+// Bootstrap a uint setting while in bootstrap mode
+// Returns ErrProposalsUnsupported once bootstrap mode is disabled, as the protocol DAO cannot submit proposals
+func BootstrapOrFailUint(rp *rocketpool.RocketPool, contractName, settingPath string, value *big.Int, opts *bind.TransactOpts) (common.Hash, error) {
+	bootstrapMode, err := GetBootstrapMode(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if bootstrapMode {
+		return BootstrapUint(rp, contractName, settingPath, value, opts)
+	}
+	return common.Hash{}, ErrProposalsUnsupported
+}
+
+// Bootstrap an address setting while in bootstrap mode
+// Returns ErrProposalsUnsupported once bootstrap mode is disabled, as the protocol DAO cannot submit proposals
+func BootstrapOrFailAddress(rp *rocketpool.RocketPool, contractName, settingPath string, value common.Address, opts *bind.TransactOpts) (common.Hash, error) {
+	bootstrapMode, err := GetBootstrapMode(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if bootstrapMode {
+		return BootstrapAddress(rp, contractName, settingPath, value, opts)
+	}
+	return common.Hash{}, ErrProposalsUnsupported
+}
+
+// Bootstrap a rewards claimer percentage while in bootstrap mode
+// Returns ErrProposalsUnsupported once bootstrap mode is disabled, as the protocol DAO cannot submit proposals
+func BootstrapOrFailClaimer(rp *rocketpool.RocketPool, contractName string, amount float64, opts *bind.TransactOpts) (common.Hash, error) {
+	bootstrapMode, err := GetBootstrapMode(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if bootstrapMode {
+		return BootstrapClaimer(rp, contractName, amount, opts)
+	}
+	return common.Hash{}, ErrProposalsUnsupported
+}
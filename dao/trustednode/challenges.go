@@ -0,0 +1,256 @@
+package trustednode
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+)
+
+// Challenge outcomes
+type ChallengeOutcome string
+
+const (
+	ChallengePending    ChallengeOutcome = "pending"    // Within the response window
+	ChallengeUnanswered ChallengeOutcome = "unanswered" // Response window passed; the member can be removed
+	ChallengeRefuted    ChallengeOutcome = "refuted"    // The member responded in time
+	ChallengeSucceeded  ChallengeOutcome = "succeeded"  // The member was removed
+)
+
+// A challenge against a member
+type Challenge struct {
+	ChallengedAddress common.Address   `json:"challengedAddress"`
+	ChallengerAddress common.Address   `json:"challengerAddress"`
+	Time              uint64           `json:"time"`
+	BlockNumber       uint64           `json:"blockNumber"`
+	TxHash            common.Hash      `json:"txHash"`
+	DeadlineBlock     uint64           `json:"deadlineBlock"`
+	DeciderAddress    common.Address   `json:"deciderAddress"`
+	DecidedTime       uint64           `json:"decidedTime"`
+	DecidedBlock      uint64           `json:"decidedBlock"`
+	Outcome           ChallengeOutcome `json:"outcome"`
+}
+
+// The status of a member's current challenge
+type ChallengeStatus struct {
+	MemberAddress   common.Address `json:"memberAddress"`
+	IsChallenged    bool           `json:"isChallenged"`
+	Challenge       *Challenge     `json:"challenge"`
+	CurrentBlock    uint64         `json:"currentBlock"`
+	BlocksRemaining uint64         `json:"blocksRemaining"`
+}
+
+// Get all challenges since fromBlock, optionally filtered by challenged member, with their outcomes at the current block
+func GetChallengeHistory(rp *rocketpool.RocketPool, memberAddress *common.Address, fromBlock *big.Int, intervalSize *big.Int) ([]Challenge, error) {
+
+	// Get contract
+	rocketDAONodeTrustedActions, err := getRocketDAONodeTrustedActions(rp)
+	if err != nil {
+		return nil, err
+	}
+	madeEvent := rocketDAONodeTrustedActions.ABI.Events["ActionChallengeMade"]
+	decidedEvent := rocketDAONodeTrustedActions.ABI.Events["ActionChallengeDecided"]
+
+	// Get the event logs from all deployments of the contract
+	topicFilter := [][]common.Hash{{madeEvent.ID, decidedEvent.ID}}
+	if memberAddress != nil {
+		topicFilter = append(topicFilter, []common.Hash{memberAddress.Hash()})
+	}
+	logs, err := eth.FilterContractLogs(rp, "rocketDAONodeTrustedActions", eth.FilterQuery{
+		FromBlock: fromBlock,
+		Topics:    topicFilter,
+	}, intervalSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the current block & challenge window
+	currentBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("Could not get current block: %w", err)
+	}
+	challengeWindow, err := getChallengeWindow(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Match challenges with their decisions
+	challenges := []Challenge{}
+	pending := make(map[common.Address]int)
+	for _, log := range logs {
+		values := make(map[string]interface{})
+		challengedAddress := common.BytesToAddress(log.Topics[1].Bytes())
+		switch log.Topics[0] {
+
+		case madeEvent.ID:
+			if err := madeEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+				return nil, fmt.Errorf("Could not decode ActionChallengeMade event: %w", err)
+			}
+			pending[challengedAddress] = len(challenges)
+			challenges = append(challenges, Challenge{
+				ChallengedAddress: challengedAddress,
+				ChallengerAddress: common.BytesToAddress(log.Topics[2].Bytes()),
+				Time:              values["time"].(*big.Int).Uint64(),
+				BlockNumber:       log.BlockNumber,
+				TxHash:            log.TxHash,
+				DeadlineBlock:     log.BlockNumber + challengeWindow,
+				Outcome:           ChallengePending,
+			})
+
+		case decidedEvent.ID:
+			ci, ok := pending[challengedAddress]
+			if !ok {
+				continue
+			}
+			if err := decidedEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+				return nil, fmt.Errorf("Could not decode ActionChallengeDecided event: %w", err)
+			}
+			decideChallenge(&challenges[ci], log, values["success"].(bool), values["time"].(*big.Int).Uint64())
+			delete(pending, challengedAddress)
+
+		}
+	}
+
+	// Check undecided challenges
+	for _, ci := range pending {
+		if currentBlock > challenges[ci].DeadlineBlock {
+			challenges[ci].Outcome = ChallengeUnanswered
+		}
+	}
+
+	// Return
+	return challenges, nil
+
+}
+
+// Monitors challenges against a member and responds to them
+type ChallengeMonitor struct {
+	RocketPool    *rocketpool.RocketPool
+	MemberAddress common.Address
+	FromBlock     *big.Int
+	IntervalSize  *big.Int
+}
+
+// Create a new challenge monitor for a member, searching for challenge events from fromBlock
+func NewChallengeMonitor(rp *rocketpool.RocketPool, memberAddress common.Address, fromBlock *big.Int, intervalSize *big.Int) *ChallengeMonitor {
+	return &ChallengeMonitor{
+		RocketPool:    rp,
+		MemberAddress: memberAddress,
+		FromBlock:     fromBlock,
+		IntervalSize:  intervalSize,
+	}
+}
+
+// Get the status of the current challenge against the member
+func (m *ChallengeMonitor) GetStatus() (ChallengeStatus, error) {
+
+	// Check challenged status
+	status := ChallengeStatus{MemberAddress: m.MemberAddress}
+	isChallenged, err := GetMemberIsChallenged(m.RocketPool, m.MemberAddress, nil)
+	if err != nil {
+		return ChallengeStatus{}, err
+	}
+	status.IsChallenged = isChallenged
+	if !isChallenged {
+		return status, nil
+	}
+
+	// Get the current challenge
+	challenges, err := GetChallengeHistory(m.RocketPool, &m.MemberAddress, m.FromBlock, m.IntervalSize)
+	if err != nil {
+		return ChallengeStatus{}, err
+	}
+	for ci := len(challenges) - 1; ci >= 0; ci-- {
+		if challenges[ci].Outcome == ChallengePending || challenges[ci].Outcome == ChallengeUnanswered {
+			status.Challenge = &challenges[ci]
+			break
+		}
+	}
+
+	// Get the remaining response window
+	currentBlock, err := m.RocketPool.Client.BlockNumber(context.Background())
+	if err != nil {
+		return ChallengeStatus{}, fmt.Errorf("Could not get current block: %w", err)
+	}
+	status.CurrentBlock = currentBlock
+	if status.Challenge != nil && status.Challenge.DeadlineBlock > currentBlock {
+		status.BlocksRemaining = status.Challenge.DeadlineBlock - currentBlock
+	}
+
+	// Return
+	return status, nil
+
+}
+
+// Refute the current challenge against the member if there is one
+// Returns the transaction hash, or nil if the member is not challenged
+func (m *ChallengeMonitor) Respond(opts *bind.TransactOpts) (*common.Hash, error) {
+	if opts.From != m.MemberAddress {
+		return nil, fmt.Errorf("Challenges against %s can only be refuted by the member", m.MemberAddress.Hex())
+	}
+	isChallenged, err := GetMemberIsChallenged(m.RocketPool, m.MemberAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !isChallenged {
+		return nil, nil
+	}
+	hash, err := DecideChallenge(m.RocketPool, m.MemberAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &hash, nil
+}
+
+// Watch for challenges against the member and refute them at an interval until the context is cancelled
+// onRefute is called with the transaction hash of each response, if set
+func (m *ChallengeMonitor) Watch(ctx context.Context, interval time.Duration, opts *bind.TransactOpts, onRefute func(common.Hash)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		hash, err := m.Respond(opts)
+		if err != nil {
+			return fmt.Errorf("Could not respond to challenge against %s: %w", m.MemberAddress.Hex(), err)
+		}
+		if hash != nil && onRefute != nil {
+			onRefute(*hash)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Record a challenge decision
+func decideChallenge(challenge *Challenge, log types.Log, success bool, decidedTime uint64) {
+	challenge.DeciderAddress = common.BytesToAddress(log.Topics[2].Bytes())
+	challenge.DecidedTime = decidedTime
+	challenge.DecidedBlock = log.BlockNumber
+	if success {
+		challenge.Outcome = ChallengeSucceeded
+	} else {
+		challenge.Outcome = ChallengeRefuted
+	}
+}
+
+// Get the challenge response window in blocks
+func getChallengeWindow(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint64, error) {
+	membersSettingsContract, err := rp.GetContract("rocketDAONodeTrustedSettingsMembers")
+	if err != nil {
+		return 0, err
+	}
+	value := new(*big.Int)
+	if err := membersSettingsContract.Call(opts, value, "getChallengeWindow"); err != nil {
+		return 0, fmt.Errorf("Could not get member challenge window period: %w", err)
+	}
+	return (*value).Uint64(), nil
+}
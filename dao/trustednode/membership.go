@@ -0,0 +1,330 @@
+package trustednode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/tokens"
+)
+
+// Membership lifecycle stages
+type MembershipStage string
+
+const (
+	StageNotInvited    MembershipStage = "notInvited"    // No invite proposal has been executed
+	StageInviteExpired MembershipStage = "inviteExpired" // The invite's action window has passed
+	StageInvited       MembershipStage = "invited"       // The invite was executed and the member can join
+	StageMember        MembershipStage = "member"        // The node is a member
+	StageLeaveExpired  MembershipStage = "leaveExpired"  // The leave's action window has passed
+	StageLeaveApproved MembershipStage = "leaveApproved" // The leave was executed and the member can leave
+)
+
+// The next step in a member's lifecycle
+type MembershipStep string
+
+const (
+	StepProposeInvite MembershipStep = "proposeInvite" // A member must propose an invite for the node
+	StepAcquireRPL    MembershipStep = "acquireRPL"    // The node needs more RPL for the bond
+	StepApproveRPL    MembershipStep = "approveRPL"    // The node must approve the RPL bond for transfer
+	StepJoin          MembershipStep = "join"          // The node can join
+	StepProposeLeave  MembershipStep = "proposeLeave"  // The member may propose to leave
+	StepWaitForLeave  MembershipStep = "waitForLeave"  // The member can't leave until there are more members
+	StepLeave         MembershipStep = "leave"         // The member can leave
+)
+
+// Errors
+var (
+	ErrNotInvited               = errors.New("The node does not have an executed invite proposal")
+	ErrActionWindowExpired      = errors.New("The proposal action window has expired")
+	ErrAlreadyMember            = errors.New("The node is already a trusted node DAO member")
+	ErrNotMember                = errors.New("The node is not a trusted node DAO member")
+	ErrNoLeaveProposal          = errors.New("The member does not have an executed leave proposal")
+	ErrMinimumMemberCount       = errors.New("The trusted node DAO is at its minimum member count")
+	ErrInsufficientRPL          = errors.New("The node does not have enough RPL for the bond")
+	ErrInsufficientRPLAllowance = errors.New("The node has not approved enough RPL for the bond")
+)
+
+// A node's progress through the trusted node DAO membership lifecycle
+type MembershipStatus struct {
+	Address            common.Address  `json:"address"`
+	Stage              MembershipStage `json:"stage"`
+	NextStep           MembershipStep  `json:"nextStep"`
+	IsMember           bool            `json:"isMember"`
+	InviteExecutedTime uint64          `json:"inviteExecutedTime"`
+	LeaveExecutedTime  uint64          `json:"leaveExecutedTime"`
+	ActionTime         uint64          `json:"actionTime"`
+	ActionWindowEnd    uint64          `json:"actionWindowEnd"`
+	CurrentTime        uint64          `json:"currentTime"`
+	RPLBond            *big.Int        `json:"rplBond"`
+	RPLBalance         *big.Int        `json:"rplBalance"`
+	RPLAllowance       *big.Int        `json:"rplAllowance"`
+	MemberCount        uint64          `json:"memberCount"`
+	MinimumMemberCount uint64          `json:"minimumMemberCount"`
+}
+
+// Get a node's membership lifecycle status
+func GetMembershipStatus(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (MembershipStatus, error) {
+
+	// Get actions contract address
+	rocketDAONodeTrustedActionsAddress, err := rp.GetAddress("rocketDAONodeTrustedActions")
+	if err != nil {
+		return MembershipStatus{}, err
+	}
+
+	// Get call options block number
+	var blockNumber *big.Int
+	if opts != nil {
+		blockNumber = opts.BlockNumber
+	}
+
+	// Data
+	var wg errgroup.Group
+	status := MembershipStatus{Address: nodeAddress}
+
+	// Load data
+	wg.Go(func() error {
+		header, err := rp.Client.HeaderByNumber(context.Background(), blockNumber)
+		if err == nil {
+			status.CurrentTime = header.Time
+		} else {
+			err = fmt.Errorf("Could not get block header: %w", err)
+		}
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		status.IsMember, err = GetMemberExists(rp, nodeAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		status.InviteExecutedTime, err = GetMemberInviteProposalExecutedTime(rp, nodeAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		status.LeaveExecutedTime, err = GetMemberLeaveProposalExecutedTime(rp, nodeAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		status.ActionTime, err = getProposalActionTime(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		status.RPLBond, err = getRPLBond(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		status.RPLBalance, err = tokens.GetRPLBalance(rp, nodeAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		status.RPLAllowance, err = tokens.GetRPLAllowance(rp, nodeAddress, *rocketDAONodeTrustedActionsAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		status.MemberCount, err = GetMemberCount(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		status.MinimumMemberCount, err = GetMinimumMemberCount(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return MembershipStatus{}, err
+	}
+
+	// Return
+	status.Evaluate()
+	return status, nil
+
+}
+
+// Set the status stage, next step & action window end from its loaded data
+func (s *MembershipStatus) Evaluate() {
+	s.ActionWindowEnd = 0
+	if s.IsMember {
+		if s.LeaveExecutedTime == 0 {
+			s.Stage = StageMember
+			s.NextStep = StepProposeLeave
+			return
+		}
+		s.ActionWindowEnd = s.LeaveExecutedTime + s.ActionTime
+		if s.CurrentTime >= s.ActionWindowEnd {
+			s.Stage = StageLeaveExpired
+			s.NextStep = StepProposeLeave
+			return
+		}
+		s.Stage = StageLeaveApproved
+		if s.MemberCount <= s.MinimumMemberCount {
+			s.NextStep = StepWaitForLeave
+		} else {
+			s.NextStep = StepLeave
+		}
+		return
+	}
+	if s.InviteExecutedTime == 0 {
+		s.Stage = StageNotInvited
+		s.NextStep = StepProposeInvite
+		return
+	}
+	s.ActionWindowEnd = s.InviteExecutedTime + s.ActionTime
+	if s.CurrentTime >= s.ActionWindowEnd {
+		s.Stage = StageInviteExpired
+		s.NextStep = StepProposeInvite
+		return
+	}
+	s.Stage = StageInvited
+	switch {
+	case s.RPLBalance.Cmp(s.RPLBond) < 0:
+		s.NextStep = StepAcquireRPL
+	case s.RPLAllowance.Cmp(s.RPLBond) < 0:
+		s.NextStep = StepApproveRPL
+	default:
+		s.NextStep = StepJoin
+	}
+}
+
+// Get the seconds remaining in the current action window
+func (s MembershipStatus) GetActionTimeRemaining() uint64 {
+	if s.ActionWindowEnd <= s.CurrentTime {
+		return 0
+	}
+	return s.ActionWindowEnd - s.CurrentTime
+}
+
+// Check the preconditions for joining the trusted node DAO
+func (s MembershipStatus) CanJoin() error {
+	switch s.Stage {
+	case StageNotInvited:
+		return ErrNotInvited
+	case StageInviteExpired:
+		return ErrActionWindowExpired
+	case StageMember, StageLeaveExpired, StageLeaveApproved:
+		return ErrAlreadyMember
+	}
+	if s.RPLBalance.Cmp(s.RPLBond) < 0 {
+		return ErrInsufficientRPL
+	}
+	if s.RPLAllowance.Cmp(s.RPLBond) < 0 {
+		return ErrInsufficientRPLAllowance
+	}
+	return nil
+}
+
+// Check the preconditions for proposing to leave the trusted node DAO
+func (s MembershipStatus) CanProposeLeave() error {
+	if !s.IsMember {
+		return ErrNotMember
+	}
+	if s.MemberCount <= s.MinimumMemberCount {
+		return ErrMinimumMemberCount
+	}
+	return nil
+}
+
+// Check the preconditions for leaving the trusted node DAO
+func (s MembershipStatus) CanLeave() error {
+	switch s.Stage {
+	case StageNotInvited, StageInviteExpired, StageInvited:
+		return ErrNotMember
+	case StageMember:
+		return ErrNoLeaveProposal
+	case StageLeaveExpired:
+		return ErrActionWindowExpired
+	}
+	if s.MemberCount <= s.MinimumMemberCount {
+		return ErrMinimumMemberCount
+	}
+	return nil
+}
+
+// Approve the RPL bond for transfer by the trusted node DAO
+func ApproveRPLBond(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAONodeTrustedActionsAddress, err := rp.GetAddress("rocketDAONodeTrustedActions")
+	if err != nil {
+		return common.Hash{}, err
+	}
+	rplBond, err := getRPLBond(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return tokens.ApproveRPL(rp, *rocketDAONodeTrustedActionsAddress, rplBond, opts)
+}
+
+// Join the trusted node DAO after checking the membership preconditions
+func JoinChecked(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (common.Hash, error) {
+	status, err := GetMembershipStatus(rp, opts.From, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := status.CanJoin(); err != nil {
+		return common.Hash{}, fmt.Errorf("Could not join the trusted node DAO: %w", err)
+	}
+	return Join(rp, opts)
+}
+
+// Propose leaving the trusted node DAO after checking the membership preconditions
+func ProposeMemberLeaveChecked(rp *rocketpool.RocketPool, message string, opts *bind.TransactOpts) (uint64, common.Hash, error) {
+	status, err := GetMembershipStatus(rp, opts.From, nil)
+	if err != nil {
+		return 0, common.Hash{}, err
+	}
+	if err := status.CanProposeLeave(); err != nil {
+		return 0, common.Hash{}, fmt.Errorf("Could not propose leaving the trusted node DAO: %w", err)
+	}
+	return ProposeMemberLeave(rp, message, opts.From, opts)
+}
+
+// Leave the trusted node DAO after checking the membership preconditions
+func LeaveChecked(rp *rocketpool.RocketPool, rplBondRefundAddress common.Address, opts *bind.TransactOpts) (common.Hash, error) {
+	status, err := GetMembershipStatus(rp, opts.From, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := status.CanLeave(); err != nil {
+		return common.Hash{}, fmt.Errorf("Could not leave the trusted node DAO: %w", err)
+	}
+	return Leave(rp, rplBondRefundAddress, opts)
+}
+
+// Get the RPL bond required to join
+func getRPLBond(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+	membersSettingsContract, err := rp.GetContract("rocketDAONodeTrustedSettingsMembers")
+	if err != nil {
+		return nil, err
+	}
+	value := new(*big.Int)
+	if err := membersSettingsContract.Call(opts, value, "getRPLBond"); err != nil {
+		return nil, fmt.Errorf("Could not get member RPL bond amount: %w", err)
+	}
+	return *value, nil
+}
+
+// Get the period during which an action can be performed on an executed proposal in seconds
+func getProposalActionTime(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint64, error) {
+	proposalsSettingsContract, err := rp.GetContract("rocketDAONodeTrustedSettingsProposals")
+	if err != nil {
+		return 0, err
+	}
+	value := new(*big.Int)
+	if err := proposalsSettingsContract.Call(opts, value, "getActionTime"); err != nil {
+		return 0, fmt.Errorf("Could not get proposal action period: %w", err)
+	}
+	return (*value).Uint64(), nil
+}
@@ -13,6 +13,9 @@ import (
 	"github.com/PatriceVignola/rocketpool-go/utils/strings"
 )
 
+// Config
+const ProposalsDAOName = "rocketDAONodeTrustedProposals"
+
 // Estimate the gas of ProposeInviteMember
 func EstimateProposeInviteMemberGas(rp *rocketpool.RocketPool, message string, newMemberAddress common.Address, newMemberId, newMemberUrl string, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
 	rocketDAONodeTrustedProposals, err := getRocketDAONodeTrustedProposals(rp)
@@ -306,5 +309,5 @@ var rocketDAONodeTrustedProposalsLock sync.Mutex
 func getRocketDAONodeTrustedProposals(rp *rocketpool.RocketPool) (*rocketpool.Contract, error) {
 	rocketDAONodeTrustedProposalsLock.Lock()
 	defer rocketDAONodeTrustedProposalsLock.Unlock()
-	return rp.GetContract("rocketDAONodeTrustedProposals")
+	return rp.GetContract(ProposalsDAOName)
 }
@@ -0,0 +1,358 @@
+package trustednode
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/PatriceVignola/rocketpool-go/dao"
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/storage"
+)
+
+// Upgrade types
+const (
+	UpgradeContractType = "upgradeContract"
+	AddContractType     = "addContract"
+	UpgradeABIType      = "upgradeABI"
+	AddABIType          = "addABI"
+)
+
+// The differences between a contract's current and proposed ABIs
+type ABIDiff struct {
+	AddedMethods   []string `json:"addedMethods"`
+	RemovedMethods []string `json:"removedMethods"`
+	ChangedMethods []string `json:"changedMethods"`
+	AddedEvents    []string `json:"addedEvents"`
+	RemovedEvents  []string `json:"removedEvents"`
+	ChangedEvents  []string `json:"changedEvents"`
+}
+
+// The data a contract upgrade verification is based on
+type UpgradeVerificationData struct {
+	ProposalID       uint64
+	UpgradeType      string
+	ContractName     string
+	ContractAddress  common.Address
+	ProposedABI      *abi.ABI
+	CurrentABI       *abi.ABI
+	CurrentAddress   *common.Address
+	AddressInUse     bool   // Whether the contract address is already a network contract
+	AddressInUseBy   string // The name of the network contract at the contract address, if registered
+	Code             []byte
+	ExpectedCodeHash *common.Hash
+}
+
+// A contract upgrade review report
+type UpgradeVerification struct {
+	ProposalID       uint64          `json:"proposalId"`
+	UpgradeType      string          `json:"upgradeType"`
+	ContractName     string          `json:"contractName"`
+	ContractAddress  common.Address  `json:"contractAddress"`
+	CurrentAddress   *common.Address `json:"currentAddress"`
+	HasCode          bool            `json:"hasCode"`
+	CodeHash         common.Hash     `json:"codeHash"`
+	ExpectedCodeHash *common.Hash    `json:"expectedCodeHash"`
+	CodeHashMatches  bool            `json:"codeHashMatches"`
+	ABIDiff          ABIDiff         `json:"abiDiff"`
+	Problems         []string        `json:"problems"`
+	Warnings         []string        `json:"warnings"`
+}
+
+// Get the hash of deployed contract bytecode
+func GetCodeHash(code []byte) common.Hash {
+	return crypto.Keccak256Hash(code)
+}
+
+// Verify a submitted contract upgrade proposal against an optional expected deployed bytecode hash
+func VerifyUpgradeProposal(rp *rocketpool.RocketPool, proposalId uint64, expectedCodeHash *common.Hash, opts *bind.CallOpts) (UpgradeVerification, error) {
+
+	// Get & decode the proposal payload
+	payload, err := dao.GetProposalPayload(rp, proposalId, opts)
+	if err != nil {
+		return UpgradeVerification{}, err
+	}
+	decoded, err := dao.DecodeProposalPayload(rp, ProposalsDAOName, payload, nil, opts)
+	if err != nil {
+		return UpgradeVerification{}, err
+	}
+	if decoded.Method != "proposalUpgrade" || len(decoded.Arguments) != 4 {
+		return UpgradeVerification{}, fmt.Errorf("Proposal %d is not a contract upgrade proposal", proposalId)
+	}
+	upgradeType := decoded.Arguments[0].Value.(string)
+	contractName := decoded.Arguments[1].Value.(string)
+	compressedAbi := decoded.Arguments[2].Value.(string)
+	contractAddress := decoded.Arguments[3].Value.(common.Address)
+
+	// Decode the proposed ABI
+	proposedAbi, err := rocketpool.DecodeAbi(compressedAbi)
+	if err != nil {
+		return UpgradeVerification{}, fmt.Errorf("Could not decode proposal %d contract ABI: %w", proposalId, err)
+	}
+
+	// Verify
+	verification, err := verifyUpgrade(rp, upgradeType, contractName, proposedAbi, contractAddress, expectedCodeHash, opts)
+	if err != nil {
+		return UpgradeVerification{}, err
+	}
+	verification.ProposalID = proposalId
+	return verification, nil
+
+}
+
+// Verify a contract upgrade before proposing it, against an optional expected deployed bytecode hash
+func VerifyUpgrade(rp *rocketpool.RocketPool, upgradeType, contractName, contractAbi string, contractAddress common.Address, expectedCodeHash *common.Hash, opts *bind.CallOpts) (UpgradeVerification, error) {
+	proposedAbi, err := abi.JSON(strings.NewReader(contractAbi))
+	if err != nil {
+		return UpgradeVerification{}, fmt.Errorf("Could not parse contract ABI: %w", err)
+	}
+	return verifyUpgrade(rp, upgradeType, contractName, &proposedAbi, contractAddress, expectedCodeHash, opts)
+}
+
+// Build a contract upgrade review report
+func BuildUpgradeVerification(data UpgradeVerificationData) UpgradeVerification {
+	verification := UpgradeVerification{
+		ProposalID:       data.ProposalID,
+		UpgradeType:      data.UpgradeType,
+		ContractName:     data.ContractName,
+		ContractAddress:  data.ContractAddress,
+		CurrentAddress:   data.CurrentAddress,
+		ExpectedCodeHash: data.ExpectedCodeHash,
+		Problems:         []string{},
+		Warnings:         []string{},
+	}
+
+	// Check the upgrade type
+	replacesContract := (data.UpgradeType == UpgradeContractType || data.UpgradeType == UpgradeABIType)
+	deploysContract := (data.UpgradeType == UpgradeContractType || data.UpgradeType == AddContractType)
+	switch data.UpgradeType {
+	case UpgradeContractType, AddContractType, UpgradeABIType, AddABIType:
+	default:
+		verification.Problems = append(verification.Problems, fmt.Sprintf("Unknown upgrade type '%s'", data.UpgradeType))
+	}
+	if replacesContract && data.CurrentABI == nil {
+		verification.Problems = append(verification.Problems, fmt.Sprintf("Contract %s does not exist and can't be upgraded", data.ContractName))
+	}
+	if !replacesContract && data.CurrentABI != nil {
+		verification.Problems = append(verification.Problems, fmt.Sprintf("Contract %s already exists and can't be added", data.ContractName))
+	}
+
+	// Check the deployed code
+	if deploysContract {
+		verification.HasCode = (len(data.Code) > 0)
+		if verification.HasCode {
+			verification.CodeHash = GetCodeHash(data.Code)
+		} else {
+			verification.Problems = append(verification.Problems, fmt.Sprintf("No contract code is deployed at %s", data.ContractAddress.Hex()))
+		}
+		if data.ExpectedCodeHash == nil {
+			verification.Warnings = append(verification.Warnings, "No expected bytecode hash was supplied, so the deployed code is unverified")
+		} else if verification.HasCode {
+			verification.CodeHashMatches = (verification.CodeHash == *data.ExpectedCodeHash)
+			if !verification.CodeHashMatches {
+				verification.Problems = append(verification.Problems, fmt.Sprintf("Deployed bytecode hash %s does not match the expected hash %s", verification.CodeHash.Hex(), data.ExpectedCodeHash.Hex()))
+			}
+		}
+		if data.CurrentAddress != nil && *data.CurrentAddress == data.ContractAddress {
+			verification.Problems = append(verification.Problems, fmt.Sprintf("Contract %s is already deployed at %s", data.ContractName, data.ContractAddress.Hex()))
+		} else if data.AddressInUse && data.AddressInUseBy != "" {
+			verification.Problems = append(verification.Problems, fmt.Sprintf("Address %s is already used by network contract %s", data.ContractAddress.Hex(), data.AddressInUseBy))
+		} else if data.AddressInUse {
+			verification.Problems = append(verification.Problems, fmt.Sprintf("Address %s is already used by a network contract", data.ContractAddress.Hex()))
+		}
+	}
+
+	// Diff the ABIs
+	verification.ABIDiff = DiffABIs(data.CurrentABI, data.ProposedABI)
+	if len(verification.ABIDiff.RemovedMethods) > 0 || len(verification.ABIDiff.ChangedMethods) > 0 {
+		verification.Warnings = append(verification.Warnings, "The upgrade removes or changes existing methods, which may break callers")
+	}
+
+	// Return
+	return verification
+
+}
+
+// Get whether the upgrade passed verification
+func (v UpgradeVerification) IsVerified() bool {
+	return len(v.Problems) == 0
+}
+
+// Format the review report
+func (v UpgradeVerification) String() string {
+	var sb strings.Builder
+	if v.ProposalID > 0 {
+		fmt.Fprintf(&sb, "Proposal %d: %s %s\n", v.ProposalID, v.UpgradeType, v.ContractName)
+	} else {
+		fmt.Fprintf(&sb, "%s %s\n", v.UpgradeType, v.ContractName)
+	}
+	if v.UpgradeType == UpgradeContractType || v.UpgradeType == AddContractType {
+		fmt.Fprintf(&sb, "Contract address: %s\n", v.ContractAddress.Hex())
+		if v.CurrentAddress != nil {
+			fmt.Fprintf(&sb, "Current address: %s\n", v.CurrentAddress.Hex())
+		}
+		if v.HasCode {
+			fmt.Fprintf(&sb, "Deployed bytecode hash: %s\n", v.CodeHash.Hex())
+		} else {
+			fmt.Fprintf(&sb, "Deployed bytecode: none\n")
+		}
+		if v.ExpectedCodeHash != nil {
+			fmt.Fprintf(&sb, "Expected bytecode hash: %s (match: %t)\n", v.ExpectedCodeHash.Hex(), v.CodeHashMatches)
+		}
+	}
+	writeReportSection(&sb, "Added methods", v.ABIDiff.AddedMethods)
+	writeReportSection(&sb, "Removed methods", v.ABIDiff.RemovedMethods)
+	writeReportSection(&sb, "Changed methods", v.ABIDiff.ChangedMethods)
+	writeReportSection(&sb, "Added events", v.ABIDiff.AddedEvents)
+	writeReportSection(&sb, "Removed events", v.ABIDiff.RemovedEvents)
+	writeReportSection(&sb, "Changed events", v.ABIDiff.ChangedEvents)
+	writeReportSection(&sb, "Problems", v.Problems)
+	writeReportSection(&sb, "Warnings", v.Warnings)
+	fmt.Fprintf(&sb, "Verified: %t\n", v.IsVerified())
+	return sb.String()
+}
+
+// Get the methods & events added, removed or changed between two ABIs
+// A nil ABI is treated as empty
+func DiffABIs(current, proposed *abi.ABI) ABIDiff {
+
+	// Get method & event signatures by name
+	currentMethods, currentEvents := getABISignatures(current)
+	proposedMethods, proposedEvents := getABISignatures(proposed)
+
+	// Diff
+	diff := ABIDiff{}
+	diff.AddedMethods, diff.RemovedMethods, diff.ChangedMethods = diffSignatures(currentMethods, proposedMethods)
+	diff.AddedEvents, diff.RemovedEvents, diff.ChangedEvents = diffSignatures(currentEvents, proposedEvents)
+
+	// Return
+	return diff
+
+}
+
+// Load the data for and verify a contract upgrade
+func verifyUpgrade(rp *rocketpool.RocketPool, upgradeType, contractName string, proposedAbi *abi.ABI, contractAddress common.Address, expectedCodeHash *common.Hash, opts *bind.CallOpts) (UpgradeVerification, error) {
+
+	// Get call options block number
+	var blockNumber *big.Int
+	if opts != nil {
+		blockNumber = opts.BlockNumber
+	}
+
+	// Data
+	data := UpgradeVerificationData{
+		UpgradeType:      upgradeType,
+		ContractName:     contractName,
+		ContractAddress:  contractAddress,
+		ProposedABI:      proposedAbi,
+		ExpectedCodeHash: expectedCodeHash,
+	}
+
+	// Get the current ABI & address
+	// Contracts being added won't have either
+	abiEncoded, err := rp.RocketStorage.GetString(opts, storage.ContractABIKey(contractName))
+	if err != nil {
+		return UpgradeVerification{}, fmt.Errorf("Could not load contract %s ABI: %w", contractName, err)
+	}
+	if abiEncoded != "" {
+		currentAbi, err := rocketpool.DecodeAbi(abiEncoded)
+		if err != nil {
+			return UpgradeVerification{}, fmt.Errorf("Could not decode contract %s ABI: %w", contractName, err)
+		}
+		data.CurrentABI = currentAbi
+	}
+	if upgradeType == UpgradeContractType {
+		currentAddress, err := rp.RocketStorage.GetAddress(opts, storage.ContractAddressKey(contractName))
+		if err != nil {
+			return UpgradeVerification{}, fmt.Errorf("Could not load contract %s address: %w", contractName, err)
+		}
+		if currentAddress != (common.Address{}) {
+			data.CurrentAddress = &currentAddress
+		}
+	}
+
+	// Check whether the contract address is already a network contract
+	if upgradeType == UpgradeContractType || upgradeType == AddContractType {
+		addressInUse, err := rp.RocketStorage.GetBool(opts, storage.ContractExistsKey(contractAddress))
+		if err != nil {
+			return UpgradeVerification{}, fmt.Errorf("Could not get network contract exists status for %s: %w", contractAddress.Hex(), err)
+		}
+		addressInUseBy, err := rp.RocketStorage.GetString(opts, storage.ContractNameKey(contractAddress))
+		if err != nil {
+			return UpgradeVerification{}, fmt.Errorf("Could not get network contract name for %s: %w", contractAddress.Hex(), err)
+		}
+		data.AddressInUse = addressInUse
+		data.AddressInUseBy = addressInUseBy
+	}
+
+	// Get the deployed code
+	if upgradeType == UpgradeContractType || upgradeType == AddContractType {
+		code, err := rp.Client.CodeAt(context.Background(), contractAddress, blockNumber)
+		if err != nil {
+			return UpgradeVerification{}, fmt.Errorf("Could not get code at %s: %w", contractAddress.Hex(), err)
+		}
+		data.Code = code
+	}
+
+	// Return
+	return BuildUpgradeVerification(data), nil
+
+}
+
+// Get the method & event signatures of an ABI by name
+func getABISignatures(contractAbi *abi.ABI) (map[string]string, map[string]string) {
+	methods := make(map[string]string)
+	events := make(map[string]string)
+	if contractAbi == nil {
+		return methods, events
+	}
+	for name, method := range contractAbi.Methods {
+		methods[name] = method.String()
+	}
+	for name, event := range contractAbi.Events {
+		events[name] = event.String()
+	}
+	return methods, events
+}
+
+// Get the signatures added, removed or changed between two sets, sorted by name
+func diffSignatures(current, proposed map[string]string) ([]string, []string, []string) {
+	added := []string{}
+	removed := []string{}
+	changed := []string{}
+	for name, signature := range proposed {
+		currentSignature, ok := current[name]
+		if !ok {
+			added = append(added, signature)
+		} else if currentSignature != signature {
+			changed = append(changed, fmt.Sprintf("%s -> %s", currentSignature, signature))
+		}
+	}
+	for name, signature := range current {
+		if _, ok := proposed[name]; !ok {
+			removed = append(removed, signature)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+	return added, removed, changed
+}
+
+// Write a list section of a review report
+func writeReportSection(sb *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "%s:\n", title)
+	for _, item := range items {
+		fmt.Fprintf(sb, "  - %s\n", item)
+	}
+}
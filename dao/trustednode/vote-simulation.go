@@ -0,0 +1,241 @@
+package trustednode
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/PatriceVignola/rocketpool-go/dao"
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	rptypes "github.com/PatriceVignola/rocketpool-go/types"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+)
+
+// The weight of a single member's vote
+var MemberVoteWeight = eth.EthToWei(1)
+
+// The data a proposal vote simulation is based on
+type VoteSimulationData struct {
+	Proposal    dao.ProposalDetails
+	Tally       dao.ProposalTally
+	Members     []MemberDetails
+	MemberVoted map[common.Address]bool
+	Quorum      *big.Int
+	ExecuteTime uint64
+	CurrentTime uint64
+}
+
+// The simulated outcome of a proposal vote
+type VoteSimulation struct {
+	ProposalID              uint64                  `json:"proposalId"`
+	State                   rptypes.ProposalState   `json:"state"`
+	VotesRequired           *big.Int                `json:"votesRequired"`
+	VotesFor                *big.Int                `json:"votesFor"`
+	VotesAgainst            *big.Int                `json:"votesAgainst"`
+	QuorumVotesRequired     *big.Int                `json:"quorumVotesRequired"`
+	EligibleVoters          []common.Address        `json:"eligibleVoters"`
+	RemainingVoters         []common.Address        `json:"remainingVoters"`
+	AdditionalVotesRequired uint64                  `json:"additionalVotesRequired"`
+	CanPass                 bool                    `json:"canPass"`
+	CanBeDefeated           bool                    `json:"canBeDefeated"`
+	ReachableStates         []rptypes.ProposalState `json:"reachableStates"`
+	ExpiryTime              uint64                  `json:"expiryTime"`
+	ExpiresIfUnexecuted     bool                    `json:"expiresIfUnexecuted"`
+	TimeUntilExpiry         uint64                  `json:"timeUntilExpiry"`
+}
+
+// Simulate the possible outcomes of a trusted node DAO proposal vote
+func GetProposalVoteSimulation(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.CallOpts) (VoteSimulation, error) {
+
+	// Get call options block number
+	var blockNumber *big.Int
+	if opts != nil {
+		blockNumber = opts.BlockNumber
+	}
+
+	// Data
+	var wg errgroup.Group
+	data := VoteSimulationData{}
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		data.Proposal, err = dao.GetProposalDetails(rp, proposalId, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		data.Tally, err = dao.GetProposalTally(rp, proposalId, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		data.Members, err = GetMembers(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		data.Quorum, err = getQuorum(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		data.ExecuteTime, err = getProposalExecuteTime(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		header, err := rp.Client.HeaderByNumber(context.Background(), blockNumber)
+		if err == nil {
+			data.CurrentTime = header.Time
+		} else {
+			err = fmt.Errorf("Could not get block header: %w", err)
+		}
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return VoteSimulation{}, err
+	}
+
+	// Load member vote receipts in batches
+	voted := make([]bool, len(data.Members))
+	for bsi := 0; bsi < len(data.Members); bsi += MemberDetailsBatchSize {
+
+		// Get batch start & end index
+		msi := bsi
+		mei := bsi + MemberDetailsBatchSize
+		if mei > len(data.Members) {
+			mei = len(data.Members)
+		}
+
+		// Load receipts
+		var wg errgroup.Group
+		for mi := msi; mi < mei; mi++ {
+			mi := mi
+			wg.Go(func() error {
+				memberVoted, err := dao.GetProposalMemberVoted(rp, proposalId, data.Members[mi].Address, opts)
+				if err == nil {
+					voted[mi] = memberVoted
+				}
+				return err
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return VoteSimulation{}, err
+		}
+
+	}
+	data.MemberVoted = make(map[common.Address]bool)
+	for mi, member := range data.Members {
+		data.MemberVoted[member.Address] = voted[mi]
+	}
+
+	// Return
+	return SimulateProposalVote(data), nil
+
+}
+
+// Simulate the possible outcomes of a proposal vote from its current tally
+func SimulateProposalVote(data VoteSimulationData) VoteSimulation {
+	proposal := data.Proposal
+	simulation := VoteSimulation{
+		ProposalID:          proposal.ID,
+		State:               proposal.State,
+		VotesRequired:       new(big.Int).Set(data.Tally.VotesRequired),
+		VotesFor:            new(big.Int).Set(data.Tally.VotesFor),
+		VotesAgainst:        new(big.Int).Set(data.Tally.VotesAgainst),
+		QuorumVotesRequired: new(big.Int).Mul(big.NewInt(int64(len(data.Members))), data.Quorum),
+		EligibleVoters:      []common.Address{},
+		RemainingVoters:     []common.Address{},
+	}
+
+	// Get eligible & remaining voters
+	// Members can only vote on proposals created after they joined
+	for _, member := range data.Members {
+		if member.JoinedTime >= proposal.CreatedTime {
+			continue
+		}
+		simulation.EligibleVoters = append(simulation.EligibleVoters, member.Address)
+		if !data.MemberVoted[member.Address] {
+			simulation.RemainingVoters = append(simulation.RemainingVoters, member.Address)
+		}
+	}
+
+	// Get the additional votes required to pass
+	shortfall := new(big.Int).Sub(simulation.VotesRequired, simulation.VotesFor)
+	if shortfall.Sign() > 0 {
+		additionalVotes, remainder := new(big.Int).QuoRem(shortfall, MemberVoteWeight, new(big.Int))
+		if remainder.Sign() > 0 {
+			additionalVotes.Add(additionalVotes, big.NewInt(1))
+		}
+		simulation.AdditionalVotesRequired = additionalVotes.Uint64()
+	}
+
+	// Get the reachable states
+	// Votes are final, and a proposal succeeds as soon as it reaches the votes required
+	switch proposal.State {
+	case rptypes.Pending, rptypes.Active:
+		simulation.CanPass = simulation.AdditionalVotesRequired <= uint64(len(simulation.RemainingVoters))
+		simulation.CanBeDefeated = simulation.AdditionalVotesRequired > 0
+		simulation.ReachableStates = []rptypes.ProposalState{rptypes.Cancelled}
+		if simulation.CanPass {
+			simulation.ReachableStates = append(simulation.ReachableStates, rptypes.Succeeded, rptypes.Executed, rptypes.Expired)
+		}
+		if simulation.CanBeDefeated {
+			simulation.ReachableStates = append(simulation.ReachableStates, rptypes.Defeated)
+		}
+	case rptypes.Succeeded:
+		simulation.CanPass = true
+		simulation.ReachableStates = []rptypes.ProposalState{rptypes.Executed, rptypes.Expired}
+	case rptypes.Executed, rptypes.Expired:
+		simulation.CanPass = true
+		simulation.ReachableStates = []rptypes.ProposalState{}
+	default:
+		simulation.ReachableStates = []rptypes.ProposalState{}
+	}
+
+	// Get the expiry time
+	simulation.ExpiryTime = proposal.ExpiryTime
+	if simulation.ExpiryTime == 0 {
+		simulation.ExpiryTime = proposal.EndTime + data.ExecuteTime
+	}
+	simulation.ExpiresIfUnexecuted = simulation.CanPass && proposal.State != rptypes.Executed && proposal.State != rptypes.Expired
+	if simulation.ExpiresIfUnexecuted && simulation.ExpiryTime > data.CurrentTime {
+		simulation.TimeUntilExpiry = simulation.ExpiryTime - data.CurrentTime
+	}
+
+	// Return
+	return simulation
+
+}
+
+// Get the member proposal quorum threshold
+func getQuorum(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+	membersSettingsContract, err := rp.GetContract("rocketDAONodeTrustedSettingsMembers")
+	if err != nil {
+		return nil, err
+	}
+	value := new(*big.Int)
+	if err := membersSettingsContract.Call(opts, value, "getQuorum"); err != nil {
+		return nil, fmt.Errorf("Could not get member quorum threshold: %w", err)
+	}
+	return *value, nil
+}
+
+// Get the period a passed proposal can be executed in seconds
+func getProposalExecuteTime(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint64, error) {
+	proposalsSettingsContract, err := rp.GetContract("rocketDAONodeTrustedSettingsProposals")
+	if err != nil {
+		return 0, err
+	}
+	value := new(*big.Int)
+	if err := proposalsSettingsContract.Call(opts, value, "getExecuteTime"); err != nil {
+		return 0, fmt.Errorf("Could not get proposal execution period: %w", err)
+	}
+	return (*value).Uint64(), nil
+}
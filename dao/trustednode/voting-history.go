@@ -0,0 +1,189 @@
+package trustednode
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/PatriceVignola/rocketpool-go/dao"
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	rptypes "github.com/PatriceVignola/rocketpool-go/types"
+)
+
+// A member's vote on a proposal
+type MemberVote string
+
+const (
+	VoteFor       MemberVote = "for"
+	VoteAgainst   MemberVote = "against"
+	VoteAbstained MemberVote = "abstained"
+)
+
+// The member votes on a proposal
+// Members who joined after the proposal was created are omitted, as are non-voters while voting is open
+type ProposalVotes struct {
+	ProposalID      uint64                        `json:"proposalId"`
+	ProposerAddress common.Address                `json:"proposerAddress"`
+	State           rptypes.ProposalState         `json:"state"`
+	Votes           map[common.Address]MemberVote `json:"votes"`
+}
+
+// A member's voting statistics
+type MemberVotingStats struct {
+	Address           common.Address `json:"address"`
+	ID                string         `json:"id"`
+	ProposalsCreated  uint64         `json:"proposalsCreated"`
+	EligibleProposals uint64         `json:"eligibleProposals"`
+	VotesFor          uint64         `json:"votesFor"`
+	VotesAgainst      uint64         `json:"votesAgainst"`
+	Abstained         uint64         `json:"abstained"`
+	ParticipationRate float64        `json:"participationRate"`
+	DecidedVotes      uint64         `json:"decidedVotes"`
+	AgreementRate     float64        `json:"agreementRate"`
+}
+
+// The trusted node DAO voting history
+type VotingHistory struct {
+	Proposals []ProposalVotes     `json:"proposals"`
+	Members   []MemberVotingStats `json:"members"`
+}
+
+// Get the voting history of all current members over all trusted node DAO proposals
+func GetVotingHistory(rp *rocketpool.RocketPool, fromBlock *big.Int, intervalSize *big.Int, opts *bind.CallOpts) (VotingHistory, error) {
+
+	// Data
+	var wg errgroup.Group
+	var members []MemberDetails
+	var proposals []dao.ProposalDetails
+	var votes []dao.ProposalVote
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		members, err = GetMembers(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		proposals, err = dao.GetDAOProposals(rp, ProposalsDAOName, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		votes, err = dao.GetProposalVotes(rp, fromBlock, intervalSize)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return VotingHistory{}, err
+	}
+
+	// Return
+	return BuildVotingHistory(members, proposals, votes), nil
+
+}
+
+// Build the voting history of members from proposals and their votes
+func BuildVotingHistory(members []MemberDetails, proposals []dao.ProposalDetails, votes []dao.ProposalVote) VotingHistory {
+
+	// Index votes by proposal
+	proposalVotes := make(map[uint64]map[common.Address]bool)
+	for _, vote := range votes {
+		if _, ok := proposalVotes[vote.ProposalID]; !ok {
+			proposalVotes[vote.ProposalID] = make(map[common.Address]bool)
+		}
+		proposalVotes[vote.ProposalID][vote.Voter] = vote.Supported
+	}
+
+	// Initialise member stats
+	stats := make([]MemberVotingStats, len(members))
+	agreedVotes := make([]uint64, len(members))
+	memberIndices := make(map[common.Address]int)
+	for mi, member := range members {
+		stats[mi] = MemberVotingStats{Address: member.Address, ID: member.ID}
+		memberIndices[member.Address] = mi
+	}
+
+	// Build vote matrix
+	sortedProposals := make([]dao.ProposalDetails, len(proposals))
+	copy(sortedProposals, proposals)
+	sort.Slice(sortedProposals, func(i, j int) bool { return sortedProposals[i].ID < sortedProposals[j].ID })
+	history := VotingHistory{Proposals: make([]ProposalVotes, len(sortedProposals))}
+	for pi, proposal := range sortedProposals {
+		votingClosed := (proposal.State != rptypes.Pending && proposal.State != rptypes.Active)
+		passed, decided := getProposalOutcome(proposal.State)
+		history.Proposals[pi] = ProposalVotes{
+			ProposalID:      proposal.ID,
+			ProposerAddress: proposal.ProposerAddress,
+			State:           proposal.State,
+			Votes:           make(map[common.Address]MemberVote),
+		}
+		if mi, ok := memberIndices[proposal.ProposerAddress]; ok {
+			stats[mi].ProposalsCreated++
+		}
+		for mi, member := range members {
+
+			// Check eligibility
+			// Members can only vote on proposals created after they joined
+			if member.JoinedTime >= proposal.CreatedTime || proposal.State == rptypes.Cancelled {
+				continue
+			}
+
+			// Record vote
+			supported, voted := proposalVotes[proposal.ID][member.Address]
+			switch {
+			case voted && supported:
+				history.Proposals[pi].Votes[member.Address] = VoteFor
+				stats[mi].VotesFor++
+			case voted:
+				history.Proposals[pi].Votes[member.Address] = VoteAgainst
+				stats[mi].VotesAgainst++
+			case votingClosed:
+				history.Proposals[pi].Votes[member.Address] = VoteAbstained
+				stats[mi].Abstained++
+			default:
+				continue
+			}
+			stats[mi].EligibleProposals++
+
+			// Check agreement with outcome
+			if voted && decided {
+				stats[mi].DecidedVotes++
+				if supported == passed {
+					agreedVotes[mi]++
+				}
+			}
+
+		}
+	}
+
+	// Calculate rates
+	for mi := range stats {
+		if stats[mi].EligibleProposals > 0 {
+			stats[mi].ParticipationRate = float64(stats[mi].VotesFor+stats[mi].VotesAgainst) / float64(stats[mi].EligibleProposals)
+		}
+		if stats[mi].DecidedVotes > 0 {
+			stats[mi].AgreementRate = float64(agreedVotes[mi]) / float64(stats[mi].DecidedVotes)
+		}
+	}
+	history.Members = stats
+
+	// Return
+	return history
+
+}
+
+// Get whether a proposal passed, and whether its outcome is decided
+func getProposalOutcome(state rptypes.ProposalState) (bool, bool) {
+	switch state {
+	case rptypes.Succeeded, rptypes.Executed, rptypes.Expired:
+		return true, true
+	case rptypes.Defeated:
+		return false, true
+	}
+	return false, false
+}
@@ -0,0 +1,129 @@
+package dao
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+)
+
+// A vote on a proposal
+type ProposalVote struct {
+	ProposalID  uint64         `json:"proposalId"`
+	Voter       common.Address `json:"voter"`
+	Supported   bool           `json:"supported"`
+	Time        uint64         `json:"time"`
+	BlockNumber uint64         `json:"blockNumber"`
+	TxHash      common.Hash    `json:"txHash"`
+}
+
+// Get all proposal votes since fromBlock from ProposalVoted events
+func GetProposalVotes(rp *rocketpool.RocketPool, fromBlock *big.Int, intervalSize *big.Int) ([]ProposalVote, error) {
+
+	// Get contract
+	rocketDAOProposal, err := getRocketDAOProposal(rp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the event logs
+	event := rocketDAOProposal.ABI.Events["ProposalVoted"]
+	addressFilter := []common.Address{*rocketDAOProposal.Address}
+	topicFilter := [][]common.Hash{{event.ID}}
+	logs, err := eth.GetLogs(rp, addressFilter, topicFilter, intervalSize, fromBlock, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the events
+	indexed := abi.Arguments{}
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	votes := make([]ProposalVote, len(logs))
+	for li, log := range logs {
+		values := make(map[string]interface{})
+		if err := abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:]); err != nil {
+			return nil, fmt.Errorf("Could not decode ProposalVoted event topics: %w", err)
+		}
+		if err := event.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return nil, fmt.Errorf("Could not decode ProposalVoted event data: %w", err)
+		}
+		votes[li] = ProposalVote{
+			ProposalID:  values["proposalID"].(*big.Int).Uint64(),
+			Voter:       values["voter"].(common.Address),
+			Supported:   values["supported"].(bool),
+			Time:        values["time"].(*big.Int).Uint64(),
+			BlockNumber: log.BlockNumber,
+			TxHash:      log.TxHash,
+		}
+	}
+
+	// Return
+	return votes, nil
+
+}
+
+// Exact proposal vote tallies
+type ProposalTally struct {
+	VotesRequired *big.Int `json:"votesRequired"`
+	VotesFor      *big.Int `json:"votesFor"`
+	VotesAgainst  *big.Int `json:"votesAgainst"`
+}
+
+// Get a proposal's exact vote tallies
+func GetProposalTally(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.CallOpts) (ProposalTally, error) {
+
+	// Get contract
+	rocketDAOProposal, err := getRocketDAOProposal(rp)
+	if err != nil {
+		return ProposalTally{}, err
+	}
+
+	// Data
+	var wg errgroup.Group
+	votesRequired := new(*big.Int)
+	votesFor := new(*big.Int)
+	votesAgainst := new(*big.Int)
+
+	// Load data
+	wg.Go(func() error {
+		if err := rocketDAOProposal.Call(opts, votesRequired, "getVotesRequired", big.NewInt(int64(proposalId))); err != nil {
+			return fmt.Errorf("Could not get proposal %d votes required: %w", proposalId, err)
+		}
+		return nil
+	})
+	wg.Go(func() error {
+		if err := rocketDAOProposal.Call(opts, votesFor, "getVotesFor", big.NewInt(int64(proposalId))); err != nil {
+			return fmt.Errorf("Could not get proposal %d votes for: %w", proposalId, err)
+		}
+		return nil
+	})
+	wg.Go(func() error {
+		if err := rocketDAOProposal.Call(opts, votesAgainst, "getVotesAgainst", big.NewInt(int64(proposalId))); err != nil {
+			return fmt.Errorf("Could not get proposal %d votes against: %w", proposalId, err)
+		}
+		return nil
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return ProposalTally{}, err
+	}
+
+	// Return
+	return ProposalTally{
+		VotesRequired: *votesRequired,
+		VotesFor:      *votesFor,
+		VotesAgainst:  *votesAgainst,
+	}, nil
+
+}
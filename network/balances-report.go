@@ -0,0 +1,285 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/PatriceVignola/rocketpool-go/deposit"
+	"github.com/PatriceVignola/rocketpool-go/minipool"
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/tokens"
+	rptypes "github.com/PatriceVignola/rocketpool-go/types"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+)
+
+// Settings
+const MinipoolBalanceDetailsBatchSize = 20
+
+// Beacon chain validator status at an epoch
+type BeaconValidatorStatus struct {
+	Exists          bool   `json:"exists"`
+	Balance         uint64 `json:"balance"` // gwei
+	ActivationEpoch uint64 `json:"activationEpoch"`
+	ExitEpoch       uint64 `json:"exitEpoch"`
+}
+
+// A source of beacon chain data used to calculate minipool balances
+type BeaconBalanceSource interface {
+	GetEpochAtTime(blockTime uint64) (uint64, error)
+	GetValidatorStatuses(pubkeys []rptypes.ValidatorPubkey, epoch uint64) (map[rptypes.ValidatorPubkey]BeaconValidatorStatus, error)
+}
+
+// A minipool's contribution to the network balances
+type MinipoolBalanceDetails struct {
+	Address       common.Address          `json:"address"`
+	Pubkey        rptypes.ValidatorPubkey `json:"pubkey"`
+	Status        rptypes.MinipoolStatus  `json:"status"`
+	Finalised     bool                    `json:"finalised"`
+	IsStaking     bool                    `json:"isStaking"`
+	BeaconBalance *big.Int                `json:"beaconBalance"`
+	UserBalance   *big.Int                `json:"userBalance"`
+}
+
+// The network balances to submit for a block, with their breakdown
+type BalancesReport struct {
+	Block            uint64                   `json:"block"`
+	Epoch            uint64                   `json:"epoch"`
+	DepositPool      *big.Int                 `json:"depositPool"`
+	MinipoolsTotal   *big.Int                 `json:"minipoolsTotal"`
+	MinipoolsStaking *big.Int                 `json:"minipoolsStaking"`
+	RETHContract     *big.Int                 `json:"rethContract"`
+	TotalETH         *big.Int                 `json:"totalEth"`
+	StakingETH       *big.Int                 `json:"stakingEth"`
+	RETHSupply       *big.Int                 `json:"rethSupply"`
+	Minipools        []MinipoolBalanceDetails `json:"minipools"`
+}
+
+// Calculate the network balances for the latest reportable balances block
+func CalculateBalancesReport(rp *rocketpool.RocketPool, beacon BeaconBalanceSource, opts *bind.CallOpts) (BalancesReport, error) {
+	blockNumber, err := GetLatestReportableBalancesBlock(rp, opts)
+	if err != nil {
+		return BalancesReport{}, err
+	}
+	return CalculateBalancesReportAtBlock(rp, beacon, blockNumber.Uint64())
+}
+
+// Calculate the network balances at a block
+func CalculateBalancesReportAtBlock(rp *rocketpool.RocketPool, beacon BeaconBalanceSource, blockNumber uint64) (BalancesReport, error) {
+
+	// Get the block header & beacon epoch
+	header, err := rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return BalancesReport{}, fmt.Errorf("Could not get block %d header: %w", blockNumber, err)
+	}
+	epoch, err := beacon.GetEpochAtTime(header.Time)
+	if err != nil {
+		return BalancesReport{}, fmt.Errorf("Could not get beacon epoch for block %d: %w", blockNumber, err)
+	}
+	opts := &bind.CallOpts{BlockNumber: header.Number}
+
+	// Data
+	var wg errgroup.Group
+	var depositPoolBalance *big.Int
+	var rethContractBalance *big.Int
+	var rethSupply *big.Int
+	var minipoolDetails []MinipoolBalanceDetails
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		depositPoolBalance, err = deposit.GetBalance(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		rethContractBalance, err = tokens.GetRETHContractETHBalance(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		rethSupply, err = tokens.GetRETHTotalSupply(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		minipoolDetails, err = getMinipoolBalanceDetails(rp, beacon, epoch, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return BalancesReport{}, err
+	}
+
+	// Sum minipool user balances
+	minipoolsTotal := big.NewInt(0)
+	minipoolsStaking := big.NewInt(0)
+	for _, details := range minipoolDetails {
+		minipoolsTotal.Add(minipoolsTotal, details.UserBalance)
+		if details.IsStaking {
+			minipoolsStaking.Add(minipoolsStaking, details.UserBalance)
+		}
+	}
+
+	// Get total ETH balance
+	totalEth := big.NewInt(0)
+	totalEth.Add(totalEth, depositPoolBalance)
+	totalEth.Add(totalEth, minipoolsTotal)
+	totalEth.Add(totalEth, rethContractBalance)
+
+	// Return
+	return BalancesReport{
+		Block:            blockNumber,
+		Epoch:            epoch,
+		DepositPool:      depositPoolBalance,
+		MinipoolsTotal:   minipoolsTotal,
+		MinipoolsStaking: minipoolsStaking,
+		RETHContract:     rethContractBalance,
+		TotalETH:         totalEth,
+		StakingETH:       new(big.Int).Set(minipoolsStaking),
+		RETHSupply:       rethSupply,
+		Minipools:        minipoolDetails,
+	}, nil
+
+}
+
+// Load the balance details of every minipool in the network
+func getMinipoolBalanceDetails(rp *rocketpool.RocketPool, beacon BeaconBalanceSource, epoch uint64, opts *bind.CallOpts) ([]MinipoolBalanceDetails, error) {
+
+	// Get minipool addresses
+	minipoolAddresses, err := minipool.GetMinipoolAddresses(rp, opts)
+	if err != nil {
+		return []MinipoolBalanceDetails{}, err
+	}
+
+	// Load minipool contracts, statuses and user deposit balances in batches
+	minipools := make([]*minipool.Minipool, len(minipoolAddresses))
+	details := make([]MinipoolBalanceDetails, len(minipoolAddresses))
+	for bsi := 0; bsi < len(minipoolAddresses); bsi += MinipoolBalanceDetailsBatchSize {
+
+		// Get batch start & end index
+		msi := bsi
+		mei := bsi + MinipoolBalanceDetailsBatchSize
+		if mei > len(minipoolAddresses) {
+			mei = len(minipoolAddresses)
+		}
+
+		// Load details
+		var wg errgroup.Group
+		for mi := msi; mi < mei; mi++ {
+			mi := mi
+			wg.Go(func() error {
+				mp, err := minipool.NewMinipool(rp, minipoolAddresses[mi])
+				if err != nil {
+					return err
+				}
+				status, err := mp.GetStatus(opts)
+				if err != nil {
+					return err
+				}
+				finalised, err := mp.GetFinalised(opts)
+				if err != nil {
+					return err
+				}
+				userDepositBalance, err := mp.GetUserDepositBalance(opts)
+				if err != nil {
+					return err
+				}
+				pubkey, err := minipool.GetMinipoolPubkey(rp, mp.Address, opts)
+				if err != nil {
+					return err
+				}
+				minipools[mi] = mp
+				details[mi] = MinipoolBalanceDetails{
+					Address:       mp.Address,
+					Pubkey:        pubkey,
+					Status:        status,
+					Finalised:     finalised,
+					BeaconBalance: big.NewInt(0),
+					UserBalance:   userDepositBalance,
+				}
+				return nil
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return []MinipoolBalanceDetails{}, err
+		}
+
+	}
+
+	// Get the pubkeys of minipools which may have a validator on the beacon chain
+	pubkeys := []rptypes.ValidatorPubkey{}
+	for _, mpDetails := range details {
+		if !mpDetails.Finalised && mpDetails.Status != rptypes.Initialized && mpDetails.Status != rptypes.Prelaunch {
+			pubkeys = append(pubkeys, mpDetails.Pubkey)
+		}
+	}
+
+	// Get validator statuses
+	validators := make(map[rptypes.ValidatorPubkey]BeaconValidatorStatus)
+	if len(pubkeys) > 0 {
+		validators, err = beacon.GetValidatorStatuses(pubkeys, epoch)
+		if err != nil {
+			return []MinipoolBalanceDetails{}, fmt.Errorf("Could not get beacon validator statuses: %w", err)
+		}
+	}
+
+	// Calculate user balances from beacon balances in batches
+	for bsi := 0; bsi < len(details); bsi += MinipoolBalanceDetailsBatchSize {
+
+		// Get batch start & end index
+		msi := bsi
+		mei := bsi + MinipoolBalanceDetailsBatchSize
+		if mei > len(details) {
+			mei = len(details)
+		}
+
+		// Calculate balances
+		var wg errgroup.Group
+		for mi := msi; mi < mei; mi++ {
+			mi := mi
+			wg.Go(func() error {
+				mpDetails := &details[mi]
+
+				// Finalised minipools hold no user balance
+				if mpDetails.Finalised {
+					mpDetails.UserBalance = big.NewInt(0)
+					return nil
+				}
+
+				// Use the user deposit balance until the validator is active
+				if mpDetails.Status == rptypes.Initialized || mpDetails.Status == rptypes.Prelaunch {
+					return nil
+				}
+				validator, ok := validators[mpDetails.Pubkey]
+				if !ok || !validator.Exists || validator.ActivationEpoch >= epoch {
+					return nil
+				}
+
+				// Get the user share of the beacon balance
+				beaconBalance := new(big.Int).Mul(new(big.Int).SetUint64(validator.Balance), big.NewInt(int64(eth.WeiPerGwei)))
+				userBalance, err := minipools[mi].CalculateUserShare(beaconBalance, opts)
+				if err != nil {
+					return err
+				}
+				mpDetails.BeaconBalance = beaconBalance
+				mpDetails.UserBalance = userBalance
+				mpDetails.IsStaking = validator.ExitEpoch > epoch
+				return nil
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return []MinipoolBalanceDetails{}, err
+		}
+
+	}
+
+	// Return
+	return details, nil
+
+}
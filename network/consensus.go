@@ -0,0 +1,286 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	trustednodedao "github.com/PatriceVignola/rocketpool-go/dao/trustednode"
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+)
+
+// Settings
+const IntervalConsensusBatchSize = 20
+
+// The values submitted by a trusted node for a reporting interval
+type OracleSubmission struct {
+	Member      common.Address `json:"member"`
+	Block       uint64         `json:"block"`
+	Values      []*big.Int     `json:"values"`
+	Time        uint64         `json:"time"`
+	TxHash      common.Hash    `json:"txHash"`
+	BlockNumber uint64         `json:"blockNumber"`
+}
+
+// A set of identical submissions for a reporting interval
+type SubmissionGroup struct {
+	Values           []*big.Int       `json:"values"`
+	Members          []common.Address `json:"members"`
+	Fraction         float64          `json:"fraction"`
+	SubmissionsToGo  uint64           `json:"submissionsToGo"`
+	ConsensusReached bool             `json:"consensusReached"`
+}
+
+// The consensus state of a reporting interval
+type IntervalConsensus struct {
+	Block             uint64             `json:"block"`
+	ValueNames        []string           `json:"valueNames"`
+	MemberCount       uint64             `json:"memberCount"`
+	Threshold         float64            `json:"threshold"`
+	SubmissionsNeeded uint64             `json:"submissionsNeeded"`
+	Submissions       []OracleSubmission `json:"submissions"`
+	Groups            []SubmissionGroup  `json:"groups"`
+	ConsensusReached  bool               `json:"consensusReached"`
+	DivergentMembers  []common.Address   `json:"divergentMembers"`
+	RepeatedMembers   []common.Address   `json:"repeatedMembers"`
+	MissingMembers    []common.Address   `json:"missingMembers"`
+}
+
+// Submitted event value names
+var (
+	BalancesSubmissionValueNames = []string{"totalEth", "stakingEth", "rethSupply"}
+	PricesSubmissionValueNames   = []string{"rplPrice", "effectiveRplStake"}
+)
+
+// Get the consensus state of each balances reporting interval submitted since fromBlock
+func GetBalancesConsensus(rp *rocketpool.RocketPool, fromBlock *big.Int, intervalSize *big.Int, opts *bind.CallOpts) ([]IntervalConsensus, error) {
+	rocketNetworkBalances, err := getRocketNetworkBalances(rp)
+	if err != nil {
+		return nil, err
+	}
+	return getConsensus(rp, rocketNetworkBalances, "BalancesSubmitted", BalancesSubmissionValueNames, fromBlock, intervalSize, opts)
+}
+
+// Get the consensus state of each prices reporting interval submitted since fromBlock
+func GetPricesConsensus(rp *rocketpool.RocketPool, fromBlock *big.Int, intervalSize *big.Int, opts *bind.CallOpts) ([]IntervalConsensus, error) {
+	rocketNetworkPrices, err := getRocketNetworkPrices(rp)
+	if err != nil {
+		return nil, err
+	}
+	return getConsensus(rp, rocketNetworkPrices, "PricesSubmitted", PricesSubmissionValueNames, fromBlock, intervalSize, opts)
+}
+
+// Get the consensus state of each reporting interval for a submission event
+// Each interval is evaluated against the trusted node members and consensus threshold at the block of its latest submission
+func getConsensus(rp *rocketpool.RocketPool, contract *rocketpool.Contract, eventName string, valueNames []string, fromBlock *big.Int, intervalSize *big.Int, opts *bind.CallOpts) ([]IntervalConsensus, error) {
+
+	// Get submissions
+	submissions, err := getOracleSubmissions(rp, contract, eventName, valueNames, fromBlock, intervalSize, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group submissions by reported block
+	intervalSubmissions := make(map[uint64][]OracleSubmission)
+	for _, submission := range submissions {
+		intervalSubmissions[submission.Block] = append(intervalSubmissions[submission.Block], submission)
+	}
+	blocks := make([]uint64, 0, len(intervalSubmissions))
+	for block := range intervalSubmissions {
+		blocks = append(blocks, block)
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i] < blocks[j] })
+
+	// Build interval consensus states in batches
+	intervals := make([]IntervalConsensus, len(blocks))
+	for bsi := 0; bsi < len(blocks); bsi += IntervalConsensusBatchSize {
+
+		// Get batch start & end index
+		isi := bsi
+		iei := bsi + IntervalConsensusBatchSize
+		if iei > len(blocks) {
+			iei = len(blocks)
+		}
+
+		// Load members & threshold at each interval's latest submission
+		var wg errgroup.Group
+		for ii := isi; ii < iei; ii++ {
+			ii := ii
+			wg.Go(func() error {
+				block := blocks[ii]
+				var submittedAt uint64
+				for _, submission := range intervalSubmissions[block] {
+					if submission.BlockNumber > submittedAt {
+						submittedAt = submission.BlockNumber
+					}
+				}
+				intervalOpts := &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(submittedAt)}
+				members, err := trustednodedao.GetMemberAddresses(rp, intervalOpts)
+				if err != nil {
+					return err
+				}
+				threshold, err := protocol.GetNodeConsensusThreshold(rp, intervalOpts)
+				if err != nil {
+					return err
+				}
+				intervals[ii] = GetIntervalConsensus(block, valueNames, intervalSubmissions[block], members, threshold)
+				return nil
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return nil, err
+		}
+
+	}
+
+	// Return
+	return intervals, nil
+
+}
+
+// Get the consensus state of a single reporting interval from its submissions
+// Members may submit several different sets of values for an interval; each counts towards its own group, as on chain
+func GetIntervalConsensus(block uint64, valueNames []string, submissions []OracleSubmission, members []common.Address, threshold float64) IntervalConsensus {
+
+	// Get the number of submissions required for consensus
+	memberCount := uint64(len(members))
+	submissionsNeeded := memberCount + 1
+	for count := uint64(1); count <= memberCount; count++ {
+		if float64(count)/float64(memberCount) >= threshold {
+			submissionsNeeded = count
+			break
+		}
+	}
+
+	// Group identical submissions, counting each member once per group
+	groupIndices := make(map[string]int)
+	groups := []SubmissionGroup{}
+	submitted := make(map[common.Address]map[string]bool)
+	submitters := []common.Address{}
+	for _, submission := range submissions {
+		key := submissionValuesKey(submission.Values)
+		if _, ok := submitted[submission.Member]; !ok {
+			submitted[submission.Member] = make(map[string]bool)
+			submitters = append(submitters, submission.Member)
+		}
+		if submitted[submission.Member][key] {
+			continue
+		}
+		submitted[submission.Member][key] = true
+		gi, ok := groupIndices[key]
+		if !ok {
+			gi = len(groups)
+			groupIndices[key] = gi
+			groups = append(groups, SubmissionGroup{Values: submission.Values})
+		}
+		groups[gi].Members = append(groups[gi].Members, submission.Member)
+	}
+
+	// Get group consensus progress
+	for gi := range groups {
+		count := uint64(len(groups[gi].Members))
+		if memberCount > 0 {
+			groups[gi].Fraction = float64(count) / float64(memberCount)
+		}
+		groups[gi].ConsensusReached = (memberCount > 0 && groups[gi].Fraction >= threshold)
+		if count < submissionsNeeded {
+			groups[gi].SubmissionsToGo = submissionsNeeded - count
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return len(groups[i].Members) > len(groups[j].Members) })
+
+	// Get members who never submitted the leading group's values, members who submitted several sets of values, and members who did not submit
+	divergent := []common.Address{}
+	repeated := []common.Address{}
+	missing := []common.Address{}
+	consensusReached := (len(groups) > 0 && groups[0].ConsensusReached)
+	for _, member := range submitters {
+		if len(groups) > 0 && !submitted[member][submissionValuesKey(groups[0].Values)] {
+			divergent = append(divergent, member)
+		}
+		if len(submitted[member]) > 1 {
+			repeated = append(repeated, member)
+		}
+	}
+	for _, member := range members {
+		if _, ok := submitted[member]; !ok {
+			missing = append(missing, member)
+		}
+	}
+
+	// Return
+	return IntervalConsensus{
+		Block:             block,
+		ValueNames:        valueNames,
+		MemberCount:       memberCount,
+		Threshold:         threshold,
+		SubmissionsNeeded: submissionsNeeded,
+		Submissions:       submissions,
+		Groups:            groups,
+		ConsensusReached:  consensusReached,
+		DivergentMembers:  divergent,
+		RepeatedMembers:   repeated,
+		MissingMembers:    missing,
+	}
+
+}
+
+// Get the submissions for a submission event since fromBlock
+func getOracleSubmissions(rp *rocketpool.RocketPool, contract *rocketpool.Contract, eventName string, valueNames []string, fromBlock *big.Int, intervalSize *big.Int, opts *bind.CallOpts) ([]OracleSubmission, error) {
+
+	// Get the upper block bound
+	var toBlock *big.Int
+	if opts != nil {
+		toBlock = opts.BlockNumber
+	}
+
+	// Get the event logs
+	event := contract.ABI.Events[eventName]
+	addressFilter := []common.Address{*contract.Address}
+	topicFilter := [][]common.Hash{{event.ID}}
+	logs, err := eth.GetLogs(rp, addressFilter, topicFilter, intervalSize, fromBlock, toBlock, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the events
+	submissions := make([]OracleSubmission, len(logs))
+	for li, log := range logs {
+		values := make(map[string]interface{})
+		if err := event.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return nil, fmt.Errorf("Could not decode %s event: %w", eventName, err)
+		}
+		submission := OracleSubmission{
+			Member:      common.BytesToAddress(log.Topics[1].Bytes()),
+			Block:       values["block"].(*big.Int).Uint64(),
+			Values:      make([]*big.Int, len(valueNames)),
+			Time:        values["time"].(*big.Int).Uint64(),
+			TxHash:      log.TxHash,
+			BlockNumber: log.BlockNumber,
+		}
+		for vi, valueName := range valueNames {
+			submission.Values[vi] = values[valueName].(*big.Int)
+		}
+		submissions[li] = submission
+	}
+
+	// Return
+	return submissions, nil
+
+}
+
+// Get a comparable key for a set of submitted values
+func submissionValuesKey(values []*big.Int) string {
+	strs := make([]string, len(values))
+	for vi, value := range values {
+		strs[vi] = value.String()
+	}
+	return strings.Join(strs, ",")
+}
@@ -0,0 +1,86 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/PatriceVignola/rocketpool-go/node"
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+)
+
+// Settings
+const EffectiveRPLStakeBatchSize = 100
+
+// A source of the RPL price in ETH (as a wei value) at a block
+type RPLPriceSource interface {
+	GetRPLPrice(blockNumber uint64) (*big.Int, error)
+}
+
+// The network prices to submit for a block
+type PricesReport struct {
+	Block             uint64   `json:"block"`
+	RPLPrice          *big.Int `json:"rplPrice"`
+	EffectiveRPLStake *big.Int `json:"effectiveRplStake"`
+}
+
+// Calculate the network prices for the latest reportable prices block
+func CalculatePricesReport(rp *rocketpool.RocketPool, priceSource RPLPriceSource, opts *bind.CallOpts) (PricesReport, error) {
+	blockNumber, err := GetLatestReportablePricesBlock(rp, opts)
+	if err != nil {
+		return PricesReport{}, err
+	}
+	return CalculatePricesReportAtBlock(rp, priceSource, blockNumber.Uint64())
+}
+
+// Calculate the network prices at a block
+func CalculatePricesReportAtBlock(rp *rocketpool.RocketPool, priceSource RPLPriceSource, blockNumber uint64) (PricesReport, error) {
+
+	// Get RPL price
+	rplPrice, err := priceSource.GetRPLPrice(blockNumber)
+	if err != nil {
+		return PricesReport{}, fmt.Errorf("Could not get RPL price at block %d: %w", blockNumber, err)
+	}
+
+	// Get effective RPL stake
+	opts := &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(blockNumber)}
+	effectiveRplStake, err := CalculateEffectiveRPLStake(rp, rplPrice, opts)
+	if err != nil {
+		return PricesReport{}, err
+	}
+
+	// Return
+	return PricesReport{
+		Block:             blockNumber,
+		RPLPrice:          rplPrice,
+		EffectiveRPLStake: effectiveRplStake,
+	}, nil
+
+}
+
+// Calculate the total effective RPL stake of all nodes at an RPL price, in batches of nodes
+func CalculateEffectiveRPLStake(rp *rocketpool.RocketPool, rplPrice *big.Int, opts *bind.CallOpts) (*big.Int, error) {
+
+	// Get node count
+	nodeCount, err := node.GetNodeCount(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sum effective stake in batches
+	total := big.NewInt(0)
+	limit := big.NewInt(EffectiveRPLStakeBatchSize)
+	for i := uint64(0); i < nodeCount; i += EffectiveRPLStakeBatchSize {
+		offset := new(big.Int).SetUint64(i)
+		batchStake, err := node.CalculateTotalEffectiveRPLStake(rp, offset, limit, rplPrice, opts)
+		if err != nil {
+			return nil, err
+		}
+		total.Add(total, batchStake)
+	}
+
+	// Return
+	return total, nil
+
+}
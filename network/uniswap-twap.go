@@ -0,0 +1,182 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+)
+
+// Uniswap V2 pair contract ABI subset used to read TWAP data
+const uniswapV2PairABI = `[
+	{"constant":true,"inputs":[],"name":"token0","outputs":[{"name":"","type":"address"}],"stateMutability":"view","type":"function"},
+	{"constant":true,"inputs":[],"name":"token1","outputs":[{"name":"","type":"address"}],"stateMutability":"view","type":"function"},
+	{"constant":true,"inputs":[],"name":"getReserves","outputs":[{"name":"_reserve0","type":"uint112"},{"name":"_reserve1","type":"uint112"},{"name":"_blockTimestampLast","type":"uint32"}],"stateMutability":"view","type":"function"},
+	{"constant":true,"inputs":[],"name":"price0CumulativeLast","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"constant":true,"inputs":[],"name":"price1CumulativeLast","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+// Fixed point & overflow constants
+var (
+	uq112        = new(big.Int).Lsh(big.NewInt(1), 112)
+	uint32Range  = new(big.Int).Lsh(big.NewInt(1), 32)
+	uint256Range = new(big.Int).Lsh(big.NewInt(1), 256)
+	weiPerEth    = big.NewInt(1e18)
+)
+
+// Uniswap V2 pair reserves
+type uniswapReserves struct {
+	Reserve0           *big.Int `abi:"_reserve0"`
+	Reserve1           *big.Int `abi:"_reserve1"`
+	BlockTimestampLast uint32   `abi:"_blockTimestampLast"`
+}
+
+// RPL price source reading a time-weighted average price from a Uniswap V2 RPL/WETH pair
+type UniswapTWAPPriceSource struct {
+	RocketPool   *rocketpool.RocketPool
+	Pair         *rocketpool.Contract
+	RPLIsToken0  bool
+	WindowBlocks uint64
+}
+
+// Create a new Uniswap TWAP price source for an RPL/WETH pair
+// The TWAP is taken over the windowBlocks blocks preceding the requested block
+func NewUniswapTWAPPriceSource(rp *rocketpool.RocketPool, pairAddress common.Address, wethAddress common.Address, windowBlocks uint64) (*UniswapTWAPPriceSource, error) {
+
+	// Check window
+	if windowBlocks == 0 {
+		return nil, errors.New("Uniswap TWAP window must be at least one block")
+	}
+
+	// Create pair contract
+	pairAbi, err := abi.JSON(strings.NewReader(uniswapV2PairABI))
+	if err != nil {
+		return nil, err
+	}
+	pair := &rocketpool.Contract{
+		Contract: bind.NewBoundContract(pairAddress, pairAbi, rp.Client, rp.Client, rp.Client),
+		Address:  &pairAddress,
+		ABI:      &pairAbi,
+		Client:   rp.Client,
+	}
+
+	// Get the pair token order
+	rplAddress, err := rp.GetAddress("rocketTokenRPL")
+	if err != nil {
+		return nil, err
+	}
+	token0 := new(common.Address)
+	if err := pair.Call(nil, token0, "token0"); err != nil {
+		return nil, fmt.Errorf("Could not get Uniswap pair token0: %w", err)
+	}
+	token1 := new(common.Address)
+	if err := pair.Call(nil, token1, "token1"); err != nil {
+		return nil, fmt.Errorf("Could not get Uniswap pair token1: %w", err)
+	}
+	if !((*token0 == *rplAddress && *token1 == wethAddress) || (*token0 == wethAddress && *token1 == *rplAddress)) {
+		return nil, fmt.Errorf("Uniswap pair %s is not an RPL %s / WETH %s pair", pairAddress.Hex(), rplAddress.Hex(), wethAddress.Hex())
+	}
+
+	// Create and return
+	return &UniswapTWAPPriceSource{
+		RocketPool:   rp,
+		Pair:         pair,
+		RPLIsToken0:  (*token0 == *rplAddress),
+		WindowBlocks: windowBlocks,
+	}, nil
+
+}
+
+// Get the RPL price in ETH averaged over the window ending at a block
+func (s *UniswapTWAPPriceSource) GetRPLPrice(blockNumber uint64) (*big.Int, error) {
+
+	// Get window bounds
+	if blockNumber < s.WindowBlocks {
+		return nil, fmt.Errorf("Block %d is before the end of the first TWAP window", blockNumber)
+	}
+	startBlock := blockNumber - s.WindowBlocks
+
+	// Get cumulative prices
+	startCumulative, startTime, err := s.getCumulativePrice(startBlock)
+	if err != nil {
+		return nil, err
+	}
+	endCumulative, endTime, err := s.getCumulativePrice(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if endTime <= startTime {
+		return nil, fmt.Errorf("No time elapsed between blocks %d and %d", startBlock, blockNumber)
+	}
+
+	// Get the average price; cumulative prices are expected to overflow
+	return CalculateTWAP(startCumulative, endCumulative, endTime-startTime), nil
+
+}
+
+// Calculate a TWAP in wei from two UQ112x112 cumulative prices and the time elapsed between them
+func CalculateTWAP(startCumulative, endCumulative *big.Int, elapsed uint64) *big.Int {
+	delta := new(big.Int).Sub(endCumulative, startCumulative)
+	delta.Mod(delta, uint256Range)
+	price := new(big.Int).Div(delta, new(big.Int).SetUint64(elapsed))
+	price.Mul(price, weiPerEth)
+	return price.Div(price, uq112)
+}
+
+// Get the counterfactual cumulative RPL price at a block and the block timestamp
+func (s *UniswapTWAPPriceSource) getCumulativePrice(blockNumber uint64) (*big.Int, uint64, error) {
+
+	// Get block time
+	header, err := s.RocketPool.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, 0, fmt.Errorf("Could not get block %d header: %w", blockNumber, err)
+	}
+	opts := &bind.CallOpts{BlockNumber: header.Number}
+
+	// Get cumulative price & reserves
+	cumulativeMethod := "price1CumulativeLast"
+	if s.RPLIsToken0 {
+		cumulativeMethod = "price0CumulativeLast"
+	}
+	cumulative := new(*big.Int)
+	if err := s.Pair.Call(opts, cumulative, cumulativeMethod); err != nil {
+		return nil, 0, fmt.Errorf("Could not get Uniswap cumulative price at block %d: %w", blockNumber, err)
+	}
+	reserves := new(uniswapReserves)
+	if err := s.Pair.Call(opts, reserves, "getReserves"); err != nil {
+		return nil, 0, fmt.Errorf("Could not get Uniswap reserves at block %d: %w", blockNumber, err)
+	}
+
+	// Accumulate the spot price since the last pair update
+	rplReserve, ethReserve := reserves.Reserve1, reserves.Reserve0
+	if s.RPLIsToken0 {
+		rplReserve, ethReserve = reserves.Reserve0, reserves.Reserve1
+	}
+	price := CalculateCumulativePrice(*cumulative, rplReserve, ethReserve, reserves.BlockTimestampLast, header.Time)
+
+	// Return
+	return price, header.Time, nil
+
+}
+
+// Calculate the counterfactual UQ112x112 cumulative RPL price at a block time from the pair's last cumulative price & reserves
+// The spot price is accumulated over the time since the pair's last update, as the pair contract would on its next update
+func CalculateCumulativePrice(cumulativeLast, rplReserve, ethReserve *big.Int, blockTimestampLast uint32, blockTime uint64) *big.Int {
+	price := new(big.Int).Set(cumulativeLast)
+	elapsed := new(big.Int).Sub(new(big.Int).SetUint64(blockTime), big.NewInt(int64(blockTimestampLast)))
+	elapsed.Mod(elapsed, uint32Range)
+	if elapsed.Sign() > 0 && rplReserve.Sign() > 0 && ethReserve.Sign() > 0 {
+		spot := new(big.Int).Lsh(ethReserve, 112)
+		spot.Div(spot, rplReserve)
+		price.Add(price, spot.Mul(spot, elapsed))
+		price.Mod(price, uint256Range)
+	}
+	return price
+}
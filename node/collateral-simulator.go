@@ -0,0 +1,210 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/PatriceVignola/rocketpool-go/minipool"
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+)
+
+// The on-chain state used to simulate a node's RPL collateral
+type CollateralState struct {
+	NodeAddress             common.Address `json:"nodeAddress"`
+	Time                    uint64         `json:"time"`
+	RPLStake                *big.Int       `json:"rplStake"`
+	RPLStakedTime           uint64         `json:"rplStakedTime"`
+	ActiveMinipoolCount     uint64         `json:"activeMinipoolCount"`
+	RPLPrice                *big.Int       `json:"rplPrice"`
+	HalfDepositUserAmount   *big.Int       `json:"halfDepositUserAmount"`
+	MinimumPerMinipoolStake *big.Int       `json:"minimumPerMinipoolStake"`
+	MaximumPerMinipoolStake *big.Int       `json:"maximumPerMinipoolStake"`
+	WithdrawalCooldown      uint64         `json:"withdrawalCooldown"`
+}
+
+// The outcome of a simulated RPL stake or withdrawal
+type CollateralSimulation struct {
+	RPLStake               *big.Int `json:"rplStake"`
+	RPLPrice               *big.Int `json:"rplPrice"`
+	EffectiveRPLStake      *big.Int `json:"effectiveRplStake"`
+	MinimumRPLStake        *big.Int `json:"minimumRplStake"`
+	MaximumRPLStake        *big.Int `json:"maximumRplStake"`
+	MinipoolLimit          uint64   `json:"minipoolLimit"`
+	CooldownRemaining      uint64   `json:"cooldownRemaining"`
+	InsufficientStake      bool     `json:"insufficientStake"`
+	BelowWithdrawalMinimum bool     `json:"belowWithdrawalMinimum"`
+	WithdrawalBlocked      bool     `json:"withdrawalBlocked"`
+}
+
+// Get the state used to simulate a node's RPL collateral
+func GetCollateralState(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (CollateralState, error) {
+
+	// Get call options block number
+	var blockNumber *big.Int
+	if opts != nil {
+		blockNumber = opts.BlockNumber
+	}
+
+	// Data
+	var wg errgroup.Group
+	var blockTime uint64
+	var rplStake *big.Int
+	var rplStakedTime uint64
+	var activeMinipoolCount uint64
+	var rplPrice *big.Int
+	var halfDepositUserAmount *big.Int
+	var minimumPerMinipoolStake float64
+	var maximumPerMinipoolStake float64
+	var withdrawalCooldown uint64
+
+	// Load data
+	wg.Go(func() error {
+		header, err := rp.Client.HeaderByNumber(context.Background(), blockNumber)
+		if err != nil {
+			return fmt.Errorf("Could not get block header: %w", err)
+		}
+		blockTime = header.Time
+		return nil
+	})
+	wg.Go(func() error {
+		var err error
+		rplStake, err = GetNodeRPLStake(rp, nodeAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		rplStakedTime, err = GetNodeRPLStakedTime(rp, nodeAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		activeMinipoolCount, err = minipool.GetNodeActiveMinipoolCount(rp, nodeAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		rplPrice, err = getRPLPrice(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		halfDepositUserAmount, err = protocol.GetMinipoolHalfDepositUserAmount(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		minimumPerMinipoolStake, err = protocol.GetMinimumPerMinipoolStake(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		maximumPerMinipoolStake, err = protocol.GetMaximumPerMinipoolStake(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		withdrawalCooldown, err = protocol.GetRewardsClaimIntervalTime(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return CollateralState{}, err
+	}
+
+	// Return
+	return CollateralState{
+		NodeAddress:             nodeAddress,
+		Time:                    blockTime,
+		RPLStake:                rplStake,
+		RPLStakedTime:           rplStakedTime,
+		ActiveMinipoolCount:     activeMinipoolCount,
+		RPLPrice:                rplPrice,
+		HalfDepositUserAmount:   halfDepositUserAmount,
+		MinimumPerMinipoolStake: eth.EthToWei(minimumPerMinipoolStake),
+		MaximumPerMinipoolStake: eth.EthToWei(maximumPerMinipoolStake),
+		WithdrawalCooldown:      withdrawalCooldown,
+	}, nil
+
+}
+
+// Simulate the node's collateral after staking (positive rplDelta) or withdrawing (negative rplDelta) RPL
+// A nil rplDelta leaves the stake unchanged; a nil rplPrice uses the current network RPL price
+func (s CollateralState) Simulate(rplDelta, rplPrice *big.Int) CollateralSimulation {
+
+	// Get scenario inputs
+	if rplPrice == nil {
+		rplPrice = s.RPLPrice
+	}
+	rplStake := new(big.Int).Set(s.RPLStake)
+	if rplDelta != nil {
+		rplStake.Add(rplStake, rplDelta)
+	}
+	withdrawing := (rplDelta != nil && rplDelta.Sign() < 0)
+	staking := (rplDelta != nil && rplDelta.Sign() > 0)
+
+	// Get stake bounds & minipool limit
+	minimumStake := s.calculateStakeBound(s.MinimumPerMinipoolStake, rplPrice, s.ActiveMinipoolCount)
+	maximumStake := s.calculateStakeBound(s.MaximumPerMinipoolStake, rplPrice, s.ActiveMinipoolCount)
+	minipoolLimit := uint64(0)
+	minipoolStakeValue := new(big.Int).Mul(s.HalfDepositUserAmount, s.MinimumPerMinipoolStake)
+	if rplStake.Sign() > 0 && minipoolStakeValue.Sign() > 0 {
+		limit := new(big.Int).Mul(rplStake, rplPrice)
+		minipoolLimit = limit.Div(limit, minipoolStakeValue).Uint64()
+	}
+
+	// Get effective stake
+	effectiveStake := new(big.Int).Set(rplStake)
+	if rplStake.Sign() < 0 || rplStake.Cmp(minimumStake) < 0 {
+		effectiveStake.SetUint64(0)
+	} else if rplStake.Cmp(maximumStake) > 0 {
+		effectiveStake.Set(maximumStake)
+	}
+
+	// Get the withdrawal cooldown remaining; staking restarts the cooldown
+	stakedTime := s.RPLStakedTime
+	if staking {
+		stakedTime = s.Time
+	}
+	cooldownRemaining := uint64(0)
+	if cooldownEnd := stakedTime + s.WithdrawalCooldown; cooldownEnd > s.Time {
+		cooldownRemaining = cooldownEnd - s.Time
+	}
+
+	// Check withdrawal conditions
+	insufficientStake := (rplStake.Sign() < 0)
+	belowWithdrawalMinimum := (withdrawing && rplStake.Cmp(maximumStake) < 0)
+	withdrawalBlocked := (withdrawing && (cooldownRemaining > 0 || insufficientStake || belowWithdrawalMinimum))
+
+	// Return
+	return CollateralSimulation{
+		RPLStake:               rplStake,
+		RPLPrice:               rplPrice,
+		EffectiveRPLStake:      effectiveStake,
+		MinimumRPLStake:        minimumStake,
+		MaximumRPLStake:        maximumStake,
+		MinipoolLimit:          minipoolLimit,
+		CooldownRemaining:      cooldownRemaining,
+		InsufficientStake:      insufficientStake,
+		BelowWithdrawalMinimum: belowWithdrawalMinimum,
+		WithdrawalBlocked:      withdrawalBlocked,
+	}
+
+}
+
+// Calculate an RPL stake bound for a number of minipools at an RPL price
+func (s CollateralState) calculateStakeBound(perMinipoolStake, rplPrice *big.Int, minipoolCount uint64) *big.Int {
+	if rplPrice.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	bound := new(big.Int).Mul(s.HalfDepositUserAmount, perMinipoolStake)
+	bound.Mul(bound, new(big.Int).SetUint64(minipoolCount))
+	return bound.Div(bound, rplPrice)
+}
@@ -0,0 +1,189 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/PatriceVignola/rocketpool-go/dao/trustednode"
+	"github.com/PatriceVignola/rocketpool-go/minipool"
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
+	rptypes "github.com/PatriceVignola/rocketpool-go/types"
+	"github.com/PatriceVignola/rocketpool-go/utils"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+)
+
+// Node deposit checks
+const (
+	DepositCheckNodeRegistered  = "nodeRegistered"
+	DepositCheckDepositsEnabled = "depositsEnabled"
+	DepositCheckDepositType     = "depositType"
+	DepositCheckTrustedNode     = "trustedNode"
+	DepositCheckMinipoolLimit   = "minipoolLimit"
+	DepositCheckMinipoolAddress = "minipoolAddress"
+	DepositCheckNodeFee         = "nodeFee"
+	DepositCheckBalance         = "balance"
+)
+
+// A failed node deposit check
+type DepositCheckFailure struct {
+	Check   string `json:"check"`
+	Message string `json:"message"`
+}
+
+// The results of the node deposit pre-flight checks
+type DepositPreflight struct {
+	DepositType     rptypes.MinipoolDeposit `json:"depositType"`
+	NodeFee         float64                 `json:"nodeFee"`
+	MinipoolAddress common.Address          `json:"minipoolAddress"`
+	Failures        []DepositCheckFailure   `json:"failures"`
+}
+
+// Check whether all pre-flight checks passed
+func (p DepositPreflight) Passed() bool {
+	return len(p.Failures) == 0
+}
+
+// Run the checks the protocol applies to a node deposit before it is submitted
+// The generated minipool address is returned in the result, and compared against expectedMinipoolAddress if set
+// Errors are only returned if the checks could not be run; failed checks are listed in the result
+func CheckDeposit(rp *rocketpool.RocketPool, nodeAddress common.Address, amount *big.Int, minimumNodeFee float64, salt *big.Int, expectedMinipoolAddress *common.Address, opts *bind.CallOpts) (DepositPreflight, error) {
+
+	// Get call options block number
+	var blockNumber *big.Int
+	if opts != nil {
+		blockNumber = opts.BlockNumber
+	}
+
+	// Data
+	var wg errgroup.Group
+	var exists bool
+	var depositsEnabled bool
+	var depositType rptypes.MinipoolDeposit
+	var isTrusted bool
+	var activeMinipoolCount uint64
+	var minipoolLimit uint64
+	var nodeFee *big.Int
+	var balance *big.Int
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		exists, err = GetNodeExists(rp, nodeAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		depositsEnabled, err = protocol.GetNodeDepositEnabled(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		depositType, err = GetDepositType(rp, amount, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		isTrusted, err = trustednode.GetMemberExists(rp, nodeAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		activeMinipoolCount, err = minipool.GetNodeActiveMinipoolCount(rp, nodeAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		minipoolLimit, err = GetNodeMinipoolLimit(rp, nodeAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		nodeFee, err = getNetworkNodeFee(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		balance, err = rp.Client.BalanceAt(context.Background(), nodeAddress, blockNumber)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return DepositPreflight{}, err
+	}
+
+	// Run checks
+	failures := []DepositCheckFailure{}
+	fail := func(check, format string, args ...interface{}) {
+		failures = append(failures, DepositCheckFailure{Check: check, Message: fmt.Sprintf(format, args...)})
+	}
+	if !exists {
+		fail(DepositCheckNodeRegistered, "Node %s is not registered", nodeAddress.Hex())
+	}
+	if !depositsEnabled {
+		fail(DepositCheckDepositsEnabled, "Node deposits are currently disabled")
+	}
+	if depositType == rptypes.None {
+		fail(DepositCheckDepositType, "Deposit amount %s does not match a minipool deposit type", amount.String())
+	}
+	if depositType == rptypes.Empty && !isTrusted {
+		fail(DepositCheckTrustedNode, "Only trusted nodes can make empty deposits")
+	}
+	if activeMinipoolCount >= minipoolLimit {
+		fail(DepositCheckMinipoolLimit, "Node has %d active minipools and its RPL stake supports %d", activeMinipoolCount, minipoolLimit)
+	}
+	if nodeFee.Cmp(eth.EthToWei(minimumNodeFee)) < 0 {
+		fail(DepositCheckNodeFee, "Network node fee %f is below the minimum node fee %f", eth.WeiToEth(nodeFee), minimumNodeFee)
+	}
+	if balance.Cmp(amount) < 0 {
+		fail(DepositCheckBalance, "Node balance %s is less than the deposit amount %s", balance.String(), amount.String())
+	}
+
+	// Generate the minipool address & check it
+	var minipoolAddress common.Address
+	if depositType != rptypes.None {
+		minipoolBytecode, err := minipool.GetMinipoolBytecode(rp, opts)
+		if err != nil {
+			return DepositPreflight{}, err
+		}
+		minipoolAddress, err = utils.GenerateAddress(rp, nodeAddress, depositType, salt, minipoolBytecode)
+		if err != nil {
+			return DepositPreflight{}, err
+		}
+		if expectedMinipoolAddress != nil && minipoolAddress != *expectedMinipoolAddress {
+			fail(DepositCheckMinipoolAddress, "Expected minipool address %s does not match the generated address %s", expectedMinipoolAddress.Hex(), minipoolAddress.Hex())
+		} else if minipoolExists, err := minipool.GetMinipoolExists(rp, minipoolAddress, opts); err != nil {
+			return DepositPreflight{}, err
+		} else if minipoolExists {
+			fail(DepositCheckMinipoolAddress, "Minipool %s already exists", minipoolAddress.Hex())
+		}
+	}
+
+	// Return
+	return DepositPreflight{
+		DepositType:     depositType,
+		NodeFee:         eth.WeiToEth(nodeFee),
+		MinipoolAddress: minipoolAddress,
+		Failures:        failures,
+	}, nil
+
+}
+
+// Get the network node fee
+func getNetworkNodeFee(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+	rocketNetworkFees, err := rp.GetContract("rocketNetworkFees")
+	if err != nil {
+		return nil, err
+	}
+	nodeFee := new(*big.Int)
+	if err := rocketNetworkFees.Call(opts, nodeFee, "getNodeFee"); err != nil {
+		return nil, fmt.Errorf("Could not get network node fee: %w", err)
+	}
+	return *nodeFee, nil
+}
@@ -3,10 +3,8 @@ package node
 import (
 	"context"
 	"fmt"
-	"math"
 	"math/big"
 	"sync"
-	"time"
 
 	"github.com/PatriceVignola/rocketpool-go/dao/trustednode"
 	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
@@ -260,9 +258,8 @@ func EstimateRegisterNodeGas(rp *rocketpool.RocketPool, timezoneLocation string,
 	if err != nil {
 		return rocketpool.GasInfo{}, err
 	}
-	_, err = time.LoadLocation(timezoneLocation)
-	if err != nil {
-		return rocketpool.GasInfo{}, fmt.Errorf("Could not verify timezone [%s]: %w", timezoneLocation, err)
+	if err := ValidateTimezoneLocation(timezoneLocation); err != nil {
+		return rocketpool.GasInfo{}, err
 	}
 	return rocketNodeManager.GetTransactionGasInfo(opts, "registerNode", timezoneLocation)
 }
@@ -273,9 +270,8 @@ func RegisterNode(rp *rocketpool.RocketPool, timezoneLocation string, opts *bind
 	if err != nil {
 		return common.Hash{}, err
 	}
-	_, err = time.LoadLocation(timezoneLocation)
-	if err != nil {
-		return common.Hash{}, fmt.Errorf("Could not verify timezone [%s]: %w", timezoneLocation, err)
+	if err := ValidateTimezoneLocation(timezoneLocation); err != nil {
+		return common.Hash{}, err
 	}
 	hash, err := rocketNodeManager.Transact(opts, "registerNode", timezoneLocation)
 	if err != nil {
@@ -290,9 +286,8 @@ func EstimateSetTimezoneLocationGas(rp *rocketpool.RocketPool, timezoneLocation
 	if err != nil {
 		return rocketpool.GasInfo{}, err
 	}
-	_, err = time.LoadLocation(timezoneLocation)
-	if err != nil {
-		return rocketpool.GasInfo{}, fmt.Errorf("Could not verify timezone [%s]: %w", timezoneLocation, err)
+	if err := ValidateTimezoneLocation(timezoneLocation); err != nil {
+		return rocketpool.GasInfo{}, err
 	}
 	return rocketNodeManager.GetTransactionGasInfo(opts, "setTimezoneLocation", timezoneLocation)
 }
@@ -303,9 +298,8 @@ func SetTimezoneLocation(rp *rocketpool.RocketPool, timezoneLocation string, opt
 	if err != nil {
 		return common.Hash{}, err
 	}
-	_, err = time.LoadLocation(timezoneLocation)
-	if err != nil {
-		return common.Hash{}, fmt.Errorf("Could not verify timezone [%s]: %w", timezoneLocation, err)
+	if err := ValidateTimezoneLocation(timezoneLocation); err != nil {
+		return common.Hash{}, err
 	}
 	hash, err := rocketNodeManager.Transact(opts, "setTimezoneLocation", timezoneLocation)
 	if err != nil {
@@ -371,8 +365,8 @@ func GetBalancesSubmissions(rp *rocketpool.RocketPool, nodeAddress common.Addres
 	return &timestamps, nil
 }
 
-// Returns the most recent block number that the number of trusted nodes changed since fromBlock
-func getLatestMemberCountChangedBlock(rp *rocketpool.RocketPool, fromBlock uint64, intervalSize *big.Int) (uint64, error) {
+// Returns the most recent block number that the number of trusted nodes changed in [fromBlock, toBlock]
+func getLatestMemberCountChangedBlock(rp *rocketpool.RocketPool, fromBlock, toBlock uint64, intervalSize *big.Int) (uint64, error) {
 	// Get contracts
 	rocketDaoNodeTrustedActions, err := getRocketDAONodeTrustedActions(rp)
 	if err != nil {
@@ -383,7 +377,7 @@ func getLatestMemberCountChangedBlock(rp *rocketpool.RocketPool, fromBlock uint6
 	topicFilter := [][]common.Hash{{rocketDaoNodeTrustedActions.ABI.Events["ActionJoined"].ID, rocketDaoNodeTrustedActions.ABI.Events["ActionLeave"].ID, rocketDaoNodeTrustedActions.ABI.Events["ActionKick"].ID, rocketDaoNodeTrustedActions.ABI.Events["ActionChallengeDecided"].ID}}
 
 	// Get the event logs
-	logs, err := eth.GetLogs(rp, addressFilter, topicFilter, intervalSize, big.NewInt(int64(fromBlock)), nil, nil)
+	logs, err := eth.GetLogs(rp, addressFilter, topicFilter, intervalSize, big.NewInt(int64(fromBlock)), new(big.Int).SetUint64(toBlock), nil)
 	if err != nil {
 		return 0, err
 	}
@@ -408,173 +402,75 @@ func getLatestMemberCountChangedBlock(rp *rocketpool.RocketPool, fromBlock uint6
 
 // Calculates the participation rate of every trusted node on price submission since the last block that member count changed
 func CalculateTrustedNodePricesParticipation(rp *rocketpool.RocketPool, intervalSize *big.Int, opts *bind.CallOpts) (*TrustedNodeParticipation, error) {
-	// Get the update frequency
-	updatePricesFrequency, err := protocol.GetSubmitPricesFrequency(rp, opts)
-	if err != nil {
-		return nil, err
-	}
-	// Get the current block
-	currentBlock, err := rp.Client.HeaderByNumber(context.Background(), nil)
-	if err != nil {
-		return nil, err
-	}
-	currentBlockNumber := currentBlock.Number.Uint64()
-	// Get the block of the most recent member join (limiting to 50 intervals)
-	minBlock := (currentBlockNumber/updatePricesFrequency - 50) * updatePricesFrequency
-	latestMemberCountChangedBlock, err := getLatestMemberCountChangedBlock(rp, minBlock, intervalSize)
-	if err != nil {
-		return nil, err
-	}
-	// Get the number of current members
-	memberCount, err := trustednode.GetMemberCount(rp, nil)
-	if err != nil {
-		return nil, err
-	}
-	// Start block is the first interval after the latest join
-	startBlock := (latestMemberCountChangedBlock/updatePricesFrequency + 1) * updatePricesFrequency
-	// The number of members that have to submit each interval
-	consensus := math.Floor(float64(memberCount)/2 + 1)
-	// Check if any intervals have passed
-	intervalsPassed := uint64(0)
-	if currentBlockNumber > startBlock {
-		// The number of intervals passed
-		intervalsPassed = (currentBlockNumber-startBlock)/updatePricesFrequency + 1
-	}
-	// How many submissions would we expect per member given a random submission
-	expected := float64(intervalsPassed) * consensus / float64(memberCount)
-	// Get trusted members
-	members, err := trustednode.GetMembers(rp, nil)
-	if err != nil {
-		return nil, err
-	}
-	// Construct the epoch map
-	participationTable := make(map[common.Address][]bool)
-	// Iterate members and sum chi-square
-	submissions := make(map[common.Address]float64)
-	chi := float64(0)
-	for _, member := range members {
-		participationTable[member.Address] = make([]bool, intervalsPassed)
-		actual := 0
-		if intervalsPassed > 0 {
-			blocks, err := GetPricesSubmissions(rp, member.Address, startBlock, intervalSize)
-			if err != nil {
-				return nil, err
-			}
-			actual = len(*blocks)
-			delta := float64(actual) - expected
-			chi += (delta * delta) / expected
-			// Add to participation table
-			for _, block := range *blocks {
-				// Ignore out of step updates
-				if block%updatePricesFrequency == 0 {
-					index := block/updatePricesFrequency - startBlock/updatePricesFrequency
-					participationTable[member.Address][index] = true
-				}
-			}
-		}
-		// Save actual submission
-		submissions[member.Address] = float64(actual)
-	}
-	// Calculate inverse cumulative density function with members-1 DoF
-	probability := float64(1)
-	if intervalsPassed > 0 {
-		probability = 1 - mathext.GammaIncReg(float64(len(members)-1)/2, chi/2)
-	}
-	// Construct return value
-	participation := TrustedNodeParticipation{
-		Probability:         probability,
-		ExpectedSubmissions: expected,
-		ActualSubmissions:   submissions,
-		StartBlock:          startBlock,
-		UpdateFrequency:     updatePricesFrequency,
-		UpdateCount:         intervalsPassed,
-		Participation:       participationTable,
-	}
-	return &participation, nil
+	return calculateTrustedNodeParticipation(rp, PricesReportType, intervalSize, opts)
 }
 
 // Calculates the participation rate of every trusted node on balance submission since the last block that member count changed
 func CalculateTrustedNodeBalancesParticipation(rp *rocketpool.RocketPool, intervalSize *big.Int, opts *bind.CallOpts) (*TrustedNodeParticipation, error) {
+	return calculateTrustedNodeParticipation(rp, BalancesReportType, intervalSize, opts)
+}
+
+// Calculates the participation rate of every trusted node on a report type since the last block that member count changed
+// Every submission since the start block is counted, including repeats & out of step submissions
+func calculateTrustedNodeParticipation(rp *rocketpool.RocketPool, reportType ReportType, intervalSize *big.Int, opts *bind.CallOpts) (*TrustedNodeParticipation, error) {
 	// Get the update frequency
-	updateBalancesFrequency, err := protocol.GetSubmitBalancesFrequency(rp, opts)
+	updateFrequency, err := getReportUpdateFrequency(rp, reportType, opts)
 	if err != nil {
 		return nil, err
 	}
+	if updateFrequency == 0 {
+		return nil, fmt.Errorf("The %s update frequency is zero", reportType)
+	}
 	// Get the current block
-	currentBlock, err := rp.Client.HeaderByNumber(context.Background(), nil)
+	currentBlockNumber, err := getCallBlockNumber(rp, opts)
 	if err != nil {
 		return nil, err
 	}
-	currentBlockNumber := currentBlock.Number.Uint64()
 	// Get the block of the most recent member join (limiting to 50 intervals)
-	minBlock := (currentBlockNumber/updateBalancesFrequency - 50) * updateBalancesFrequency
-	latestMemberCountChangedBlock, err := getLatestMemberCountChangedBlock(rp, minBlock, intervalSize)
-	if err != nil {
-		return nil, err
+	minBlock := uint64(0)
+	if currentBlockNumber/updateFrequency > 50 {
+		minBlock = (currentBlockNumber/updateFrequency - 50) * updateFrequency
 	}
-	// Get the number of current members
-	memberCount, err := trustednode.GetMemberCount(rp, nil)
+	latestMemberCountChangedBlock, err := getLatestMemberCountChangedBlock(rp, minBlock, currentBlockNumber, intervalSize)
 	if err != nil {
 		return nil, err
 	}
-	// Start block is the first interval after the latest join
-	startBlock := (latestMemberCountChangedBlock/updateBalancesFrequency + 1) * updateBalancesFrequency
-	// The number of members that have to submit each interval
-	consensus := math.Floor(float64(memberCount)/2 + 1)
-	// Check if any intervals have passed
-	intervalsPassed := uint64(0)
+	// Start block is the first interval after the latest join; intervals are counted once the current block passes it
+	startBlock := (latestMemberCountChangedBlock/updateFrequency + 1) * updateFrequency
+	endBlock := startBlock - 1
 	if currentBlockNumber > startBlock {
-		// The number of intervals passed
-		intervalsPassed = (currentBlockNumber-startBlock)/updateBalancesFrequency + 1
+		endBlock = currentBlockNumber
 	}
-	// How many submissions would we expect per member given a random submission
-	expected := float64(intervalsPassed) * consensus / float64(memberCount)
-	// Get trusted members
-	members, err := trustednode.GetMembers(rp, nil)
+	report, err := calculateParticipation(rp, reportType, startBlock, endBlock, intervalSize, opts)
 	if err != nil {
 		return nil, err
 	}
-	// Construct the epoch map
+	// Iterate members and sum chi-square over all of their submissions
 	participationTable := make(map[common.Address][]bool)
-	// Iterate members and sum chi-square
 	submissions := make(map[common.Address]float64)
 	chi := float64(0)
-	for _, member := range members {
-		participationTable[member.Address] = make([]bool, intervalsPassed)
-		actual := 0
-		if intervalsPassed > 0 {
-			blocks, err := GetBalancesSubmissions(rp, member.Address, startBlock, intervalSize)
-			if err != nil {
-				return nil, err
-			}
-			actual = len(*blocks)
-			delta := float64(actual) - expected
-			chi += (delta * delta) / expected
-			// Add to participation table
-			for _, block := range *blocks {
-				// Ignore out of step updates
-				if block%updateBalancesFrequency == 0 {
-					index := block/updateBalancesFrequency - startBlock/updateBalancesFrequency
-					participationTable[member.Address][index] = true
-				}
-			}
+	for _, member := range report.Members {
+		actual := float64(member.TotalSubmissions)
+		if report.ExpectedSubmissions > 0 {
+			delta := actual - report.ExpectedSubmissions
+			chi += (delta * delta) / report.ExpectedSubmissions
 		}
-		// Save actual submission
-		submissions[member.Address] = float64(actual)
+		participationTable[member.Address] = member.Participation
+		submissions[member.Address] = actual
 	}
 	// Calculate inverse cumulative density function with members-1 DoF
 	probability := float64(1)
-	if intervalsPassed > 0 {
-		probability = 1 - mathext.GammaIncReg(float64(len(members)-1)/2, chi/2)
+	if report.ExpectedSubmissions > 0 && len(report.Members) > 1 {
+		probability = 1 - mathext.GammaIncReg(float64(len(report.Members)-1)/2, chi/2)
 	}
 	// Construct return value
 	participation := TrustedNodeParticipation{
 		Probability:         probability,
-		ExpectedSubmissions: expected,
+		ExpectedSubmissions: report.ExpectedSubmissions,
 		ActualSubmissions:   submissions,
 		StartBlock:          startBlock,
-		UpdateFrequency:     updateBalancesFrequency,
-		UpdateCount:         intervalsPassed,
+		UpdateFrequency:     updateFrequency,
+		UpdateCount:         uint64(len(report.Intervals)),
 		Participation:       participationTable,
 	}
 	return &participation, nil
@@ -0,0 +1,201 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/PatriceVignola/rocketpool-go/minipool"
+	"github.com/PatriceVignola/rocketpool-go/rewards"
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
+	"github.com/PatriceVignola/rocketpool-go/tokens"
+)
+
+// A node operator's complete status at a block
+type NodeOverview struct {
+	Block               uint64                     `json:"block"`
+	Details             NodeDetails                `json:"details"`
+	Balances            tokens.Balances            `json:"balances"`
+	WithdrawalBalances  tokens.Balances            `json:"withdrawalBalances"`
+	RPLStake            *big.Int                   `json:"rplStake"`
+	EffectiveRPLStake   *big.Int                   `json:"effectiveRplStake"`
+	MinimumRPLStake     *big.Int                   `json:"minimumRplStake"`
+	MaximumRPLStake     *big.Int                   `json:"maximumRplStake"`
+	RPLPrice            *big.Int                   `json:"rplPrice"`
+	BorrowedETH         *big.Int                   `json:"borrowedEth"`
+	CollateralRatio     float64                    `json:"collateralRatio"`
+	ClaimableRewards    *big.Int                   `json:"claimableRewards"`
+	MinipoolLimit       uint64                     `json:"minipoolLimit"`
+	ActiveMinipoolCount uint64                     `json:"activeMinipoolCount"`
+	MinipoolsRemaining  uint64                     `json:"minipoolsRemaining"`
+	Minipools           []minipool.MinipoolDetails `json:"minipools"`
+}
+
+// Get a node operator's complete status
+// All data is loaded at the opts block, or at the latest block if unset
+func GetNodeOverview(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (NodeOverview, error) {
+
+	// Pin call options to a single block
+	blockNumber, err := getCallBlockNumber(rp, opts)
+	if err != nil {
+		return NodeOverview{}, err
+	}
+	blockOpts := &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(blockNumber)}
+	if opts != nil {
+		blockOpts.Pending = opts.Pending
+		blockOpts.From = opts.From
+		blockOpts.Context = opts.Context
+	}
+
+	// Data
+	var wg errgroup.Group
+	var details NodeDetails
+	var balances tokens.Balances
+	var rplStake *big.Int
+	var effectiveRplStake *big.Int
+	var minimumRplStake *big.Int
+	var maximumRplStake *big.Int
+	var rplPrice *big.Int
+	var halfDepositUserAmount *big.Int
+	var claimableRewards *big.Int
+	var minipoolLimit uint64
+	var activeMinipoolCount uint64
+	var minipools []minipool.MinipoolDetails
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		details, err = GetNodeDetails(rp, nodeAddress, blockOpts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		balances, err = tokens.GetBalances(rp, nodeAddress, blockOpts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		rplStake, err = GetNodeRPLStake(rp, nodeAddress, blockOpts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		effectiveRplStake, err = GetNodeEffectiveRPLStake(rp, nodeAddress, blockOpts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		minimumRplStake, err = GetNodeMinimumRPLStake(rp, nodeAddress, blockOpts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		maximumRplStake, err = GetNodeMaximumRPLStake(rp, nodeAddress, blockOpts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		rplPrice, err = getRPLPrice(rp, blockOpts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		halfDepositUserAmount, err = protocol.GetMinipoolHalfDepositUserAmount(rp, blockOpts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		claimableRewards, err = rewards.GetNodeClaimRewardsAmount(rp, nodeAddress, blockOpts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		minipoolLimit, err = GetNodeMinipoolLimit(rp, nodeAddress, blockOpts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		activeMinipoolCount, err = minipool.GetNodeActiveMinipoolCount(rp, nodeAddress, blockOpts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		minipools, err = minipool.GetNodeMinipools(rp, nodeAddress, blockOpts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return NodeOverview{}, err
+	}
+
+	// Get withdrawal address balances
+	withdrawalBalances := balances
+	if details.WithdrawalAddress != nodeAddress {
+		withdrawalBalances, err = tokens.GetBalances(rp, details.WithdrawalAddress, blockOpts)
+		if err != nil {
+			return NodeOverview{}, err
+		}
+	}
+
+	// Get the value of the RPL stake relative to the user ETH borrowed by active minipools
+	borrowedEth := new(big.Int).Mul(halfDepositUserAmount, new(big.Int).SetUint64(activeMinipoolCount))
+	collateralRatio := CalculateCollateralRatio(rplStake, rplPrice, borrowedEth)
+
+	// Get the number of minipools the node can still create
+	minipoolsRemaining := uint64(0)
+	if minipoolLimit > activeMinipoolCount {
+		minipoolsRemaining = minipoolLimit - activeMinipoolCount
+	}
+
+	// Return
+	return NodeOverview{
+		Block:               blockNumber,
+		Details:             details,
+		Balances:            balances,
+		WithdrawalBalances:  withdrawalBalances,
+		RPLStake:            rplStake,
+		EffectiveRPLStake:   effectiveRplStake,
+		MinimumRPLStake:     minimumRplStake,
+		MaximumRPLStake:     maximumRplStake,
+		RPLPrice:            rplPrice,
+		BorrowedETH:         borrowedEth,
+		CollateralRatio:     collateralRatio,
+		ClaimableRewards:    claimableRewards,
+		MinipoolLimit:       minipoolLimit,
+		ActiveMinipoolCount: activeMinipoolCount,
+		MinipoolsRemaining:  minipoolsRemaining,
+		Minipools:           minipools,
+	}, nil
+
+}
+
+// Calculate the ETH value of an RPL stake as a fraction of borrowed ETH
+// Returns 0 if no ETH is borrowed
+func CalculateCollateralRatio(rplStake, rplPrice, borrowedEth *big.Int) float64 {
+	if borrowedEth.Sign() == 0 {
+		return 0
+	}
+	stakeValue := new(big.Float).Mul(new(big.Float).SetInt(rplStake), new(big.Float).SetInt(rplPrice))
+	ratio := new(big.Float).Quo(stakeValue, new(big.Float).SetInt(borrowedEth))
+	ratio.Quo(ratio, big.NewFloat(1e18))
+	ratioFloat, _ := ratio.Float64()
+	return ratioFloat
+}
+
+// Get the network RPL price in ETH
+func getRPLPrice(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+	rocketNetworkPrices, err := getRocketNetworkPrices(rp)
+	if err != nil {
+		return nil, err
+	}
+	rplPrice := new(*big.Int)
+	if err := rocketNetworkPrices.Call(opts, rplPrice, "getRPLPrice"); err != nil {
+		return nil, fmt.Errorf("Could not get network RPL price: %w", err)
+	}
+	return *rplPrice, nil
+}
@@ -0,0 +1,289 @@
+package node
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+	"gonum.org/v1/gonum/mathext"
+
+	"github.com/PatriceVignola/rocketpool-go/dao/trustednode"
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+)
+
+// Trusted node report types
+type ReportType string
+
+const (
+	BalancesReportType ReportType = "balances"
+	PricesReportType   ReportType = "prices"
+)
+
+// The participation of a trusted node in a report type over a block window
+type MemberParticipation struct {
+	Address           common.Address `json:"address"`
+	Submissions       uint64         `json:"submissions"`
+	TotalSubmissions  uint64         `json:"totalSubmissions"` // Including repeats & submissions outside of the intervals
+	Participation     []bool         `json:"participation"`
+	ParticipationRate float64        `json:"participationRate"`
+	ChiSquare         float64        `json:"chiSquare"`
+}
+
+// The participation of all trusted nodes in a report type over a block window
+type ParticipationReport struct {
+	ReportType          ReportType            `json:"reportType"`
+	StartBlock          uint64                `json:"startBlock"`
+	EndBlock            uint64                `json:"endBlock"`
+	UpdateFrequency     uint64                `json:"updateFrequency"`
+	Intervals           []uint64              `json:"intervals"`
+	ExpectedSubmissions float64               `json:"expectedSubmissions"`
+	ChiSquare           float64               `json:"chiSquare"`
+	Probability         float64               `json:"probability"`
+	Members             []MemberParticipation `json:"members"`
+}
+
+// Calculate the participation of every trusted node in a report type for the reporting intervals in [startBlock, endBlock]
+// Unlike CalculateTrustedNodePricesParticipation & CalculateTrustedNodeBalancesParticipation, only one submission per interval in the window is counted
+// Submissions are read up to the opts block (or the latest block if unset)
+func CalculateParticipation(rp *rocketpool.RocketPool, reportType ReportType, startBlock, endBlock uint64, intervalSize *big.Int, opts *bind.CallOpts) (*ParticipationReport, error) {
+	if endBlock < startBlock {
+		return nil, fmt.Errorf("Participation window end block %d is before start block %d", endBlock, startBlock)
+	}
+	return calculateParticipation(rp, reportType, startBlock, endBlock, intervalSize, opts)
+}
+
+// Calculate the participation of every trusted node in a report type for the reporting intervals in [startBlock, endBlock]
+// A window ending before its start block has no intervals
+func calculateParticipation(rp *rocketpool.RocketPool, reportType ReportType, startBlock, endBlock uint64, intervalSize *big.Int, opts *bind.CallOpts) (*ParticipationReport, error) {
+
+	// Get the submission event
+	var contract *rocketpool.Contract
+	var eventName string
+	var err error
+	switch reportType {
+	case BalancesReportType:
+		contract, err = getRocketNetworkBalances(rp)
+		eventName = "BalancesSubmitted"
+	case PricesReportType:
+		contract, err = getRocketNetworkPrices(rp)
+		eventName = "PricesSubmitted"
+	default:
+		return nil, fmt.Errorf("Unknown report type '%s'", reportType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Data
+	var wg errgroup.Group
+	var updateFrequency uint64
+	var members []common.Address
+	var submissions map[common.Address][]uint64
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		updateFrequency, err = getReportUpdateFrequency(rp, reportType, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		members, err = trustednode.GetMemberAddresses(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		submissions, err = getSubmittedBlocks(rp, contract, eventName, startBlock, intervalSize, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+	if updateFrequency == 0 {
+		return nil, fmt.Errorf("The %s update frequency is zero", reportType)
+	}
+
+	// Get the reporting intervals in the window
+	intervals := []uint64{}
+	for block := (startBlock + updateFrequency - 1) / updateFrequency * updateFrequency; block <= endBlock; block += updateFrequency {
+		intervals = append(intervals, block)
+	}
+
+	// Build report
+	report := CalculateParticipationFromSubmissions(members, submissions, intervals)
+	report.ReportType = reportType
+	report.StartBlock = startBlock
+	report.EndBlock = endBlock
+	report.UpdateFrequency = updateFrequency
+	return report, nil
+
+}
+
+// Calculate trusted node participation & chi-square statistics from the blocks each member submitted reports for
+// Submissions for blocks outside of the intervals are ignored
+func CalculateParticipationFromSubmissions(members []common.Address, submissions map[common.Address][]uint64, intervals []uint64) *ParticipationReport {
+
+	// Get interval indices
+	intervalIndices := make(map[uint64]int, len(intervals))
+	for ii, block := range intervals {
+		intervalIndices[block] = ii
+	}
+
+	// The number of submissions expected per member if the consensus set was chosen at random
+	memberCount := len(members)
+	expected := float64(0)
+	if memberCount > 0 {
+		consensus := math.Floor(float64(memberCount)/2 + 1)
+		expected = float64(len(intervals)) * consensus / float64(memberCount)
+	}
+
+	// Iterate members and sum chi-square
+	memberParticipation := make([]MemberParticipation, memberCount)
+	chi := float64(0)
+	for mi, member := range members {
+		participation := MemberParticipation{
+			Address:          member,
+			TotalSubmissions: uint64(len(submissions[member])),
+			Participation:    make([]bool, len(intervals)),
+		}
+		for _, block := range submissions[member] {
+			if ii, ok := intervalIndices[block]; ok && !participation.Participation[ii] {
+				participation.Participation[ii] = true
+				participation.Submissions++
+			}
+		}
+		if len(intervals) > 0 {
+			participation.ParticipationRate = float64(participation.Submissions) / float64(len(intervals))
+		}
+		if expected > 0 {
+			delta := float64(participation.Submissions) - expected
+			participation.ChiSquare = (delta * delta) / expected
+			chi += participation.ChiSquare
+		}
+		memberParticipation[mi] = participation
+	}
+
+	// Calculate inverse cumulative density function with members-1 DoF
+	probability := float64(1)
+	if expected > 0 && memberCount > 1 {
+		probability = 1 - mathext.GammaIncReg(float64(memberCount-1)/2, chi/2)
+	}
+
+	// Return
+	return &ParticipationReport{
+		Intervals:           intervals,
+		ExpectedSubmissions: expected,
+		ChiSquare:           chi,
+		Probability:         probability,
+		Members:             memberParticipation,
+	}
+
+}
+
+// Write a participation report as CSV, with one row per member and one column per interval
+func (r *ParticipationReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	// Header
+	header := []string{"member", "submissions", "expected", "participationRate", "chiSquare"}
+	for _, block := range r.Intervals {
+		header = append(header, strconv.FormatUint(block, 10))
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	// Member rows
+	for _, member := range r.Members {
+		row := []string{
+			member.Address.Hex(),
+			strconv.FormatUint(member.Submissions, 10),
+			strconv.FormatFloat(r.ExpectedSubmissions, 'f', -1, 64),
+			strconv.FormatFloat(member.ParticipationRate, 'f', -1, 64),
+			strconv.FormatFloat(member.ChiSquare, 'f', -1, 64),
+		}
+		for _, submitted := range member.Participation {
+			if submitted {
+				row = append(row, "1")
+			} else {
+				row = append(row, "0")
+			}
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	// Flush
+	writer.Flush()
+	return writer.Error()
+}
+
+// Get the reported blocks submitted by each trusted node since fromBlock
+func getSubmittedBlocks(rp *rocketpool.RocketPool, contract *rocketpool.Contract, eventName string, fromBlock uint64, intervalSize *big.Int, opts *bind.CallOpts) (map[common.Address][]uint64, error) {
+
+	// Get the upper block bound
+	var toBlock *big.Int
+	if opts != nil {
+		toBlock = opts.BlockNumber
+	}
+
+	// Get the event logs
+	event := contract.ABI.Events[eventName]
+	addressFilter := []common.Address{*contract.Address}
+	topicFilter := [][]common.Hash{{event.ID}}
+	logs, err := eth.GetLogs(rp, addressFilter, topicFilter, intervalSize, new(big.Int).SetUint64(fromBlock), toBlock, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the events
+	submissions := make(map[common.Address][]uint64)
+	for _, log := range logs {
+		values := make(map[string]interface{})
+		if err := event.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return nil, fmt.Errorf("Could not decode %s event: %w", eventName, err)
+		}
+		member := common.BytesToAddress(log.Topics[1].Bytes())
+		submissions[member] = append(submissions[member], values["block"].(*big.Int).Uint64())
+	}
+
+	// Return
+	return submissions, nil
+
+}
+
+// Get the update frequency of a report type
+func getReportUpdateFrequency(rp *rocketpool.RocketPool, reportType ReportType, opts *bind.CallOpts) (uint64, error) {
+	switch reportType {
+	case BalancesReportType:
+		return protocol.GetSubmitBalancesFrequency(rp, opts)
+	case PricesReportType:
+		return protocol.GetSubmitPricesFrequency(rp, opts)
+	default:
+		return 0, fmt.Errorf("Unknown report type '%s'", reportType)
+	}
+}
+
+// Get the current block number, or the opts block number if set
+func getCallBlockNumber(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint64, error) {
+	if opts != nil && opts.BlockNumber != nil {
+		return opts.BlockNumber.Uint64(), nil
+	}
+	header, err := rp.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return 0, err
+	}
+	return header.Number.Uint64(), nil
+}
@@ -0,0 +1,117 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+)
+
+// Settings
+const TimezoneCountBatchSize = 100
+
+// Region for timezones outside of a continent area (e.g. "UTC")
+const OtherTimezoneRegion = "Other"
+
+// Aggregated node counts by timezone location and region
+type TimezoneStatistics struct {
+	NodeCount       uint64            `json:"nodeCount"`
+	TimezoneCounts  map[string]uint64 `json:"timezoneCounts"`
+	RegionCounts    map[string]uint64 `json:"regionCounts"`
+	InvalidTimezone uint64            `json:"invalidTimezone"`
+}
+
+// Check that a timezone location is a named zone in the IANA timezone database
+func ValidateTimezoneLocation(timezoneLocation string) error {
+	if timezoneLocation == "" || timezoneLocation == "Local" {
+		return fmt.Errorf("Could not verify timezone [%s]: not an IANA timezone name", timezoneLocation)
+	}
+	if _, err := time.LoadLocation(timezoneLocation); err != nil {
+		return fmt.Errorf("Could not verify timezone [%s]: %w", timezoneLocation, err)
+	}
+	return nil
+}
+
+// Regions for legacy & non-geographic timezone areas
+var timezoneAreaRegions = map[string]string{
+	"Brazil":  "America",
+	"Canada":  "America",
+	"Chile":   "America",
+	"Mexico":  "America",
+	"US":      "America",
+	"Etc":     OtherTimezoneRegion,
+	"SystemV": OtherTimezoneRegion,
+}
+
+// Get the region of a timezone location (the area before the first "/", e.g. "Australia")
+func GetTimezoneRegion(timezoneLocation string) string {
+	i := strings.Index(timezoneLocation, "/")
+	if i <= 0 {
+		return OtherTimezoneRegion
+	}
+	area := timezoneLocation[:i]
+	if region, ok := timezoneAreaRegions[area]; ok {
+		return region
+	}
+	return area
+}
+
+// Get node counts aggregated by timezone location and region over all nodes
+func GetTimezoneStatistics(rp *rocketpool.RocketPool, opts *bind.CallOpts) (TimezoneStatistics, error) {
+
+	// Get node count
+	nodeCount, err := GetNodeCount(rp, opts)
+	if err != nil {
+		return TimezoneStatistics{}, err
+	}
+
+	// Aggregate timezone counts in batches
+	stats := TimezoneStatistics{
+		NodeCount:      nodeCount,
+		TimezoneCounts: make(map[string]uint64),
+		RegionCounts:   make(map[string]uint64),
+	}
+	limit := big.NewInt(TimezoneCountBatchSize)
+	for i := uint64(0); i < nodeCount; i += TimezoneCountBatchSize {
+		timezoneCounts, err := GetNodeCountPerTimezone(rp, new(big.Int).SetUint64(i), limit, opts)
+		if err != nil {
+			return TimezoneStatistics{}, err
+		}
+		for _, timezoneCount := range timezoneCounts {
+			count := timezoneCount.Count.Uint64()
+			if count == 0 {
+				continue
+			}
+			stats.TimezoneCounts[timezoneCount.Timezone] += count
+			if ValidateTimezoneLocation(timezoneCount.Timezone) != nil {
+				stats.InvalidTimezone += count
+				continue
+			}
+			stats.RegionCounts[GetTimezoneRegion(timezoneCount.Timezone)] += count
+		}
+	}
+
+	// Return
+	return stats, nil
+
+}
+
+// Get timezone locations sorted by descending node count
+func (s TimezoneStatistics) SortedTimezones() []TimezoneCount {
+	timezones := make([]TimezoneCount, 0, len(s.TimezoneCounts))
+	for timezone, count := range s.TimezoneCounts {
+		timezones = append(timezones, TimezoneCount{Timezone: timezone, Count: new(big.Int).SetUint64(count)})
+	}
+	sort.Slice(timezones, func(i, j int) bool {
+		if c := timezones[i].Count.Cmp(timezones[j].Count); c != 0 {
+			return c > 0
+		}
+		return timezones[i].Timezone < timezones[j].Timezone
+	})
+	return timezones
+}
@@ -0,0 +1,123 @@
+package settings
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/PatriceVignola/rocketpool-go/dao"
+	trustednodedao "github.com/PatriceVignola/rocketpool-go/dao/trustednode"
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+)
+
+// A trusted node DAO setting proposal, built & previewed before submission
+type SettingProposal struct {
+	RocketPool    *rocketpool.RocketPool `json:"-"`
+	Setting       SettingMetadata        `json:"setting"`
+	Value         interface{}            `json:"value"`
+	Message       string                 `json:"message"`
+	Payload       []byte                 `json:"payload"`
+	PayloadString string                 `json:"payloadString"`
+}
+
+// Build a proposal to update a trusted node DAO setting
+// The value must match the setting's Go type (e.g. float64 for fractions, uint64 for times)
+// The payload is decoded back through the proposals contract ABI for preview before the proposal is returned
+func NewTrustedNodeSettingProposal(rp *rocketpool.RocketPool, name string, value interface{}) (*SettingProposal, error) {
+
+	// Get setting
+	setting, ok := GetSettingMetadata(name)
+	if !ok {
+		return nil, fmt.Errorf("Unknown setting %s", name)
+	}
+	if setting.Control != ControlTrustedNode {
+		return nil, fmt.Errorf("Setting %s cannot be changed by trusted node DAO proposals", name)
+	}
+
+	// Get the on-chain value & payload method
+	var method string
+	var rawValue interface{}
+	if setting.GoType == "bool" {
+		boolValue, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("Invalid value type %T for setting %s; expected bool", value, name)
+		}
+		method = "proposalSettingBool"
+		rawValue = boolValue
+	} else {
+		uintValue, err := getRawSettingValue(setting, value)
+		if err != nil {
+			return nil, err
+		}
+		if err := setting.ValidateValue(uintValue); err != nil {
+			return nil, err
+		}
+		method = "proposalSettingUint"
+		rawValue = uintValue
+	}
+
+	// Encode payload
+	proposalsAbi, err := rp.GetABI(trustednodedao.ProposalsDAOName)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := proposalsAbi.Pack(method, setting.ContractName, setting.Path, rawValue)
+	if err != nil {
+		return nil, fmt.Errorf("Could not encode setting %s proposal payload: %w", name, err)
+	}
+
+	// Decode payload for preview
+	payloadString, err := dao.GetProposalPayloadString(rp, trustednodedao.ProposalsDAOName, payload)
+	if err != nil {
+		return nil, fmt.Errorf("Could not decode setting %s proposal payload: %w", name, err)
+	}
+
+	// Return
+	return &SettingProposal{
+		RocketPool:    rp,
+		Setting:       setting,
+		Value:         value,
+		Message:       fmt.Sprintf("set %s", setting.Path),
+		Payload:       payload,
+		PayloadString: payloadString,
+	}, nil
+
+}
+
+// Estimate the gas of submitting the proposal
+func (p *SettingProposal) EstimateGas(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	return trustednodedao.EstimateProposalGas(p.RocketPool, p.Message, p.Payload, opts)
+}
+
+// Submit the proposal
+// Returns the ID of the new proposal
+func (p *SettingProposal) Submit(opts *bind.TransactOpts) (uint64, common.Hash, error) {
+	return trustednodedao.SubmitProposal(p.RocketPool, p.Message, p.Payload, opts)
+}
+
+// Get the on-chain uint value of a typed setting value
+func getRawSettingValue(setting SettingMetadata, value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		if setting.GoType == "*big.Int" && v != nil {
+			return new(big.Int).Set(v), nil
+		}
+	case uint64:
+		if setting.GoType == "uint64" {
+			return new(big.Int).SetUint64(v), nil
+		}
+	case float64:
+		if setting.GoType == "float64" {
+			return eth.EthToWei(v), nil
+		}
+	case time.Duration:
+		if setting.GoType == "time.Duration" {
+			return big.NewInt(int64(v.Seconds())), nil
+		}
+	}
+	return nil, fmt.Errorf("Invalid value type %T for setting %s; expected %s", value, setting.Name, setting.GoType)
+}
@@ -0,0 +1,203 @@
+package settings
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
+	"github.com/PatriceVignola/rocketpool-go/settings/trustednode"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+)
+
+// The unit of a setting's on-chain value
+type SettingUnit string
+
+const (
+	UnitNone      SettingUnit = "none"
+	UnitWei       SettingUnit = "wei"
+	UnitFraction  SettingUnit = "fraction" // 1e18 = 100%
+	UnitSeconds   SettingUnit = "seconds"
+	UnitBlocks    SettingUnit = "blocks"
+	UnitTimestamp SettingUnit = "timestamp"
+	UnitCount     SettingUnit = "count"
+)
+
+// Who can change a setting
+type SettingControl string
+
+const (
+	ControlConstant    SettingControl = "constant"    // Fixed in the settings contract
+	ControlBootstrap   SettingControl = "bootstrap"   // Only settable by the guardian in bootstrap mode
+	ControlProtocolDAO SettingControl = "protocolDAO" // Settable by the guardian in bootstrap mode, then by the protocol DAO
+	ControlTrustedNode SettingControl = "trustedNode" // Settable by the guardian in bootstrap mode, then by trusted node DAO proposals
+)
+
+// Setting metadata
+type SettingMetadata struct {
+	Name         string         `json:"name"`
+	ContractName string         `json:"contractName"`
+	Path         string         `json:"path"`
+	GoType       string         `json:"goType"`
+	Unit         SettingUnit    `json:"unit"`
+	Description  string         `json:"description"`
+	Control      SettingControl `json:"control"`
+	Minimum      *big.Int       `json:"minimum"`
+	Maximum      *big.Int       `json:"maximum"`
+}
+
+// Bounds
+var (
+	maxFraction = eth.EthToWei(1)
+	maxBool     = big.NewInt(1)
+)
+
+// Metadata for every setting in a Settings snapshot, keyed by the names used by Diff
+var SettingsMetadata = []SettingMetadata{
+
+	// Protocol auction settings
+	{"protocol.auction.createLotEnabled", protocol.AuctionSettingsContractName, "auction.lot.create.enabled", "bool", UnitNone, "Whether new RPL auction lots can be created", ControlProtocolDAO, nil, maxBool},
+	{"protocol.auction.bidOnLotEnabled", protocol.AuctionSettingsContractName, "auction.lot.bidding.enabled", "bool", UnitNone, "Whether bids can be placed on RPL auction lots", ControlProtocolDAO, nil, maxBool},
+	{"protocol.auction.lotMinimumEthValue", protocol.AuctionSettingsContractName, "auction.lot.value.minimum", "*big.Int", UnitWei, "The minimum ETH value of RPL in a new auction lot", ControlProtocolDAO, nil, nil},
+	{"protocol.auction.lotMaximumEthValue", protocol.AuctionSettingsContractName, "auction.lot.value.maximum", "*big.Int", UnitWei, "The maximum ETH value of RPL in a new auction lot", ControlProtocolDAO, nil, nil},
+	{"protocol.auction.lotDuration", protocol.AuctionSettingsContractName, "auction.lot.duration", "uint64", UnitBlocks, "The duration of an auction lot", ControlProtocolDAO, nil, nil},
+	{"protocol.auction.lotStartingPriceRatio", protocol.AuctionSettingsContractName, "auction.price.start", "float64", UnitFraction, "The starting price of an auction lot relative to the RPL price", ControlProtocolDAO, nil, nil},
+	{"protocol.auction.lotReservePriceRatio", protocol.AuctionSettingsContractName, "auction.price.reserve", "float64", UnitFraction, "The reserve price of an auction lot relative to the RPL price", ControlProtocolDAO, nil, nil},
+
+	// Protocol deposit settings
+	{"protocol.deposit.depositEnabled", protocol.DepositSettingsContractName, "deposit.enabled", "bool", UnitNone, "Whether user deposits are enabled", ControlProtocolDAO, nil, maxBool},
+	{"protocol.deposit.assignDepositsEnabled", protocol.DepositSettingsContractName, "deposit.assign.enabled", "bool", UnitNone, "Whether deposits are assigned to minipools", ControlProtocolDAO, nil, maxBool},
+	{"protocol.deposit.minimumDeposit", protocol.DepositSettingsContractName, "deposit.minimum", "*big.Int", UnitWei, "The minimum user deposit amount", ControlProtocolDAO, nil, nil},
+	{"protocol.deposit.maximumDepositPoolSize", protocol.DepositSettingsContractName, "deposit.pool.maximum", "*big.Int", UnitWei, "The maximum size of the deposit pool", ControlProtocolDAO, nil, nil},
+	{"protocol.deposit.maximumDepositAssignments", protocol.DepositSettingsContractName, "deposit.assign.maximum", "uint64", UnitCount, "The maximum number of minipools assigned per deposit", ControlProtocolDAO, nil, nil},
+
+	// Protocol inflation settings
+	{"protocol.inflation.intervalRate", protocol.InflationSettingsContractName, "rpl.inflation.interval.rate", "float64", UnitFraction, "The RPL inflation rate per interval", ControlProtocolDAO, nil, nil},
+	{"protocol.inflation.startTime", protocol.InflationSettingsContractName, "rpl.inflation.interval.start", "uint64", UnitTimestamp, "The time RPL inflation starts; cannot be changed once inflation has started", ControlBootstrap, nil, nil},
+
+	// Protocol minipool settings
+	{"protocol.minipool.launchBalance", protocol.MinipoolSettingsContractName, "", "*big.Int", UnitWei, "The balance required to launch a minipool", ControlConstant, nil, nil},
+	{"protocol.minipool.fullDepositNodeAmount", protocol.MinipoolSettingsContractName, "", "*big.Int", UnitWei, "The node deposit amount for a full minipool", ControlConstant, nil, nil},
+	{"protocol.minipool.halfDepositNodeAmount", protocol.MinipoolSettingsContractName, "", "*big.Int", UnitWei, "The node deposit amount for a half minipool", ControlConstant, nil, nil},
+	{"protocol.minipool.emptyDepositNodeAmount", protocol.MinipoolSettingsContractName, "", "*big.Int", UnitWei, "The node deposit amount for an empty minipool", ControlConstant, nil, nil},
+	{"protocol.minipool.fullDepositUserAmount", protocol.MinipoolSettingsContractName, "", "*big.Int", UnitWei, "The user deposit amount for a full minipool", ControlConstant, nil, nil},
+	{"protocol.minipool.halfDepositUserAmount", protocol.MinipoolSettingsContractName, "", "*big.Int", UnitWei, "The user deposit amount for a half minipool", ControlConstant, nil, nil},
+	{"protocol.minipool.emptyDepositUserAmount", protocol.MinipoolSettingsContractName, "", "*big.Int", UnitWei, "The user deposit amount for an empty minipool", ControlConstant, nil, nil},
+	{"protocol.minipool.submitWithdrawableEnabled", protocol.MinipoolSettingsContractName, "minipool.submit.withdrawable.enabled", "bool", UnitNone, "Whether minipool withdrawable events can be submitted", ControlProtocolDAO, nil, maxBool},
+	{"protocol.minipool.launchTimeout", protocol.MinipoolSettingsContractName, "minipool.launch.timeout", "time.Duration", UnitSeconds, "The time a prelaunch minipool has to launch before it times out", ControlProtocolDAO, nil, nil},
+
+	// Protocol network settings
+	{"protocol.network.nodeConsensusThreshold", protocol.NetworkSettingsContractName, "network.consensus.threshold", "float64", UnitFraction, "The fraction of trusted nodes required to reach consensus on submissions", ControlProtocolDAO, nil, maxFraction},
+	{"protocol.network.submitBalancesEnabled", protocol.NetworkSettingsContractName, "network.submit.balances.enabled", "bool", UnitNone, "Whether network balances can be submitted", ControlProtocolDAO, nil, maxBool},
+	{"protocol.network.submitBalancesFrequency", protocol.NetworkSettingsContractName, "network.submit.balances.frequency", "uint64", UnitBlocks, "The interval between network balances submissions", ControlProtocolDAO, nil, nil},
+	{"protocol.network.submitPricesEnabled", protocol.NetworkSettingsContractName, "network.submit.prices.enabled", "bool", UnitNone, "Whether network prices can be submitted", ControlProtocolDAO, nil, maxBool},
+	{"protocol.network.submitPricesFrequency", protocol.NetworkSettingsContractName, "network.submit.prices.frequency", "uint64", UnitBlocks, "The interval between network prices submissions", ControlProtocolDAO, nil, nil},
+	{"protocol.network.minimumNodeFee", protocol.NetworkSettingsContractName, "network.node.fee.minimum", "float64", UnitFraction, "The minimum node commission rate", ControlProtocolDAO, nil, maxFraction},
+	{"protocol.network.targetNodeFee", protocol.NetworkSettingsContractName, "network.node.fee.target", "float64", UnitFraction, "The target node commission rate", ControlProtocolDAO, nil, maxFraction},
+	{"protocol.network.maximumNodeFee", protocol.NetworkSettingsContractName, "network.node.fee.maximum", "float64", UnitFraction, "The maximum node commission rate", ControlProtocolDAO, nil, maxFraction},
+	{"protocol.network.nodeFeeDemandRange", protocol.NetworkSettingsContractName, "network.node.fee.demand.range", "*big.Int", UnitWei, "The deposit pool demand range over which the node fee scales", ControlProtocolDAO, nil, nil},
+	{"protocol.network.targetRethCollateralRate", protocol.NetworkSettingsContractName, "network.reth.collateral.target", "float64", UnitFraction, "The target fraction of rETH backing ETH held as collateral", ControlProtocolDAO, nil, maxFraction},
+
+	// Protocol node settings
+	{"protocol.node.registrationEnabled", protocol.NodeSettingsContractName, "node.registration.enabled", "bool", UnitNone, "Whether node registrations are enabled", ControlProtocolDAO, nil, maxBool},
+	{"protocol.node.depositEnabled", protocol.NodeSettingsContractName, "node.deposit.enabled", "bool", UnitNone, "Whether node deposits are enabled", ControlProtocolDAO, nil, maxBool},
+	{"protocol.node.minimumPerMinipoolStake", protocol.NodeSettingsContractName, "node.per.minipool.stake.minimum", "float64", UnitFraction, "The minimum RPL stake per minipool as a fraction of its user deposit value", ControlProtocolDAO, nil, nil},
+	{"protocol.node.maximumPerMinipoolStake", protocol.NodeSettingsContractName, "node.per.minipool.stake.maximum", "float64", UnitFraction, "The maximum effective RPL stake per minipool as a fraction of its user deposit value", ControlProtocolDAO, nil, nil},
+
+	// Protocol rewards settings
+	{"protocol.rewards.claimerPercs.rocketClaimDAO", protocol.RewardsSettingsContractName, "", "float64", UnitFraction, "The share of RPL rewards claimable by the protocol DAO", ControlProtocolDAO, nil, maxFraction},
+	{"protocol.rewards.claimerPercs.rocketClaimNode", protocol.RewardsSettingsContractName, "", "float64", UnitFraction, "The share of RPL rewards claimable by nodes", ControlProtocolDAO, nil, maxFraction},
+	{"protocol.rewards.claimerPercs.rocketClaimTrustedNode", protocol.RewardsSettingsContractName, "", "float64", UnitFraction, "The share of RPL rewards claimable by trusted nodes", ControlProtocolDAO, nil, maxFraction},
+	{"protocol.rewards.claimersPercTotal", protocol.RewardsSettingsContractName, "", "float64", UnitFraction, "The total share of RPL rewards claimable by all claimers", ControlConstant, nil, maxFraction},
+	{"protocol.rewards.claimIntervalTime", protocol.RewardsSettingsContractName, "rpl.rewards.claim.period.time", "uint64", UnitSeconds, "The duration of an RPL rewards claim interval", ControlProtocolDAO, nil, nil},
+
+	// Trusted node members settings
+	{"trustedNode.members.quorum", trustednode.MembersSettingsContractName, trustednode.QuorumSettingPath, "float64", UnitFraction, "The fraction of members required to vote for a proposal to pass", ControlTrustedNode, nil, maxFraction},
+	{"trustedNode.members.rplBond", trustednode.MembersSettingsContractName, trustednode.RPLBondSettingPath, "*big.Int", UnitWei, "The RPL bond required to join the trusted node DAO", ControlTrustedNode, nil, nil},
+	{"trustedNode.members.minipoolUnbondedMax", trustednode.MembersSettingsContractName, trustednode.MinipoolUnbondedMaxSettingPath, "uint64", UnitCount, "The maximum number of unbonded minipools a member can run", ControlTrustedNode, nil, nil},
+	{"trustedNode.members.minipoolUnbondedMinFee", trustednode.MembersSettingsContractName, trustednode.MinipoolUnbondedMinFeeSettingPath, "uint64", UnitFraction, "The minimum node fee for a member to create unbonded minipools", ControlTrustedNode, nil, maxFraction},
+	{"trustedNode.members.challengeCooldown", trustednode.MembersSettingsContractName, trustednode.ChallengeCooldownSettingPath, "uint64", UnitBlocks, "The time a member must wait between making challenges", ControlTrustedNode, nil, nil},
+	{"trustedNode.members.challengeWindow", trustednode.MembersSettingsContractName, trustednode.ChallengeWindowSettingPath, "uint64", UnitBlocks, "The time a challenged member has to respond", ControlTrustedNode, nil, nil},
+	{"trustedNode.members.challengeCost", trustednode.MembersSettingsContractName, trustednode.ChallengeCostSettingPath, "*big.Int", UnitWei, "The ETH cost for a non-member to challenge a member", ControlTrustedNode, nil, nil},
+
+	// Trusted node minipool settings
+	{"trustedNode.minipool.scrubPeriod", trustednode.MinipoolSettingsContractName, trustednode.ScrubPeriodPath, "uint64", UnitSeconds, "The time trusted nodes have to scrub a prelaunch minipool", ControlTrustedNode, nil, nil},
+	{"trustedNode.minipool.scrubPenaltyEnabled", trustednode.MinipoolSettingsContractName, trustednode.ScrubPenaltyEnabledPath, "bool", UnitNone, "Whether scrubbed minipools are penalised", ControlTrustedNode, nil, maxBool},
+
+	// Trusted node proposals settings
+	{"trustedNode.proposals.cooldownTime", trustednode.ProposalsSettingsContractName, trustednode.CooldownTimeSettingPath, "uint64", UnitSeconds, "The time a member must wait between making proposals", ControlTrustedNode, nil, nil},
+	{"trustedNode.proposals.voteTime", trustednode.ProposalsSettingsContractName, trustednode.VoteTimeSettingPath, "uint64", UnitSeconds, "The time a proposal is open for voting", ControlTrustedNode, nil, nil},
+	{"trustedNode.proposals.voteDelayTime", trustednode.ProposalsSettingsContractName, trustednode.VoteDelayTimeSettingPath, "uint64", UnitSeconds, "The time before voting starts on a new proposal", ControlTrustedNode, nil, nil},
+	{"trustedNode.proposals.executeTime", trustednode.ProposalsSettingsContractName, trustednode.ExecuteTimeSettingPath, "uint64", UnitSeconds, "The time a passed proposal can be executed in", ControlTrustedNode, nil, nil},
+	{"trustedNode.proposals.actionTime", trustednode.ProposalsSettingsContractName, trustednode.ActionTimeSettingPath, "uint64", UnitSeconds, "The time an executed proposal's action (e.g. joining) can be performed in", ControlTrustedNode, nil, nil},
+}
+
+// Get the metadata of a setting by name
+func GetSettingMetadata(name string) (SettingMetadata, bool) {
+	for _, metadata := range SettingsMetadata {
+		if metadata.Name == name {
+			return metadata, true
+		}
+	}
+	return SettingMetadata{}, false
+}
+
+// Get the metadata of a setting by its settings contract name and path
+func GetSettingMetadataByPath(contractName, path string) (SettingMetadata, bool) {
+	if path == "" {
+		return SettingMetadata{}, false
+	}
+	for _, metadata := range SettingsMetadata {
+		if metadata.ContractName == contractName && metadata.Path == path {
+			return metadata, true
+		}
+	}
+	return SettingMetadata{}, false
+}
+
+// Format a uint setting value with its unit, for use as a dao.SettingValueFormatter
+// Values of settings without metadata are formatted as raw integers
+func FormatSettingValue(contractName, settingPath string, value *big.Int) string {
+	if metadata, ok := GetSettingMetadataByPath(contractName, settingPath); ok {
+		return metadata.FormatValue(value)
+	}
+	return value.String()
+}
+
+// Get the metadata of all settings with a control type
+func GetSettingsMetadataByControl(control SettingControl) []SettingMetadata {
+	settings := []SettingMetadata{}
+	for _, metadata := range SettingsMetadata {
+		if metadata.Control == control {
+			settings = append(settings, metadata)
+		}
+	}
+	return settings
+}
+
+// Check that a raw on-chain value is within a setting's bounds
+func (m SettingMetadata) ValidateValue(value *big.Int) error {
+	if value == nil || value.Sign() < 0 {
+		return fmt.Errorf("Invalid value for setting %s", m.Name)
+	}
+	if m.Minimum != nil && value.Cmp(m.Minimum) < 0 {
+		return fmt.Errorf("Value %s for setting %s is below the minimum %s", value.String(), m.Name, m.Minimum.String())
+	}
+	if m.Maximum != nil && value.Cmp(m.Maximum) > 0 {
+		return fmt.Errorf("Value %s for setting %s is above the maximum %s", value.String(), m.Name, m.Maximum.String())
+	}
+	return nil
+}
+
+// Format a raw on-chain value in the setting's unit (e.g. "0.51" for a fraction, "24h0m0s" for seconds)
+func (m SettingMetadata) FormatValue(value *big.Int) string {
+	switch m.Unit {
+	case UnitFraction:
+		return strconv.FormatFloat(eth.WeiToEth(value), 'f', -1, 64)
+	case UnitSeconds:
+		return (time.Duration(value.Int64()) * time.Second).String()
+	default:
+		return value.String()
+	}
+}
@@ -0,0 +1,732 @@
+package settings
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
+	"github.com/PatriceVignola/rocketpool-go/settings/trustednode"
+)
+
+// Contracts with a share of RPL rewards
+var RewardsClaimerContractNames = []string{"rocketClaimDAO", "rocketClaimNode", "rocketClaimTrustedNode"}
+
+// A snapshot of all protocol and trusted node DAO settings
+type Settings struct {
+	BlockNumber *big.Int            `json:"blockNumber"`
+	Protocol    ProtocolSettings    `json:"protocol"`
+	TrustedNode TrustedNodeSettings `json:"trustedNode"`
+}
+
+// Protocol DAO settings
+type ProtocolSettings struct {
+	Auction   AuctionSettings   `json:"auction"`
+	Deposit   DepositSettings   `json:"deposit"`
+	Inflation InflationSettings `json:"inflation"`
+	Minipool  MinipoolSettings  `json:"minipool"`
+	Network   NetworkSettings   `json:"network"`
+	Node      NodeSettings      `json:"node"`
+	Rewards   RewardsSettings   `json:"rewards"`
+}
+type AuctionSettings struct {
+	CreateLotEnabled      bool     `json:"createLotEnabled"`
+	BidOnLotEnabled       bool     `json:"bidOnLotEnabled"`
+	LotMinimumEthValue    *big.Int `json:"lotMinimumEthValue"`
+	LotMaximumEthValue    *big.Int `json:"lotMaximumEthValue"`
+	LotDuration           uint64   `json:"lotDuration"`
+	LotStartingPriceRatio float64  `json:"lotStartingPriceRatio"`
+	LotReservePriceRatio  float64  `json:"lotReservePriceRatio"`
+}
+type DepositSettings struct {
+	DepositEnabled            bool     `json:"depositEnabled"`
+	AssignDepositsEnabled     bool     `json:"assignDepositsEnabled"`
+	MinimumDeposit            *big.Int `json:"minimumDeposit"`
+	MaximumDepositPoolSize    *big.Int `json:"maximumDepositPoolSize"`
+	MaximumDepositAssignments uint64   `json:"maximumDepositAssignments"`
+}
+type InflationSettings struct {
+	IntervalRate float64 `json:"intervalRate"`
+	StartTime    uint64  `json:"startTime"`
+}
+type MinipoolSettings struct {
+	LaunchBalance             *big.Int      `json:"launchBalance"`
+	FullDepositNodeAmount     *big.Int      `json:"fullDepositNodeAmount"`
+	HalfDepositNodeAmount     *big.Int      `json:"halfDepositNodeAmount"`
+	EmptyDepositNodeAmount    *big.Int      `json:"emptyDepositNodeAmount"`
+	FullDepositUserAmount     *big.Int      `json:"fullDepositUserAmount"`
+	HalfDepositUserAmount     *big.Int      `json:"halfDepositUserAmount"`
+	EmptyDepositUserAmount    *big.Int      `json:"emptyDepositUserAmount"`
+	SubmitWithdrawableEnabled bool          `json:"submitWithdrawableEnabled"`
+	LaunchTimeout             time.Duration `json:"launchTimeout"`
+}
+type NetworkSettings struct {
+	NodeConsensusThreshold   float64  `json:"nodeConsensusThreshold"`
+	SubmitBalancesEnabled    bool     `json:"submitBalancesEnabled"`
+	SubmitBalancesFrequency  uint64   `json:"submitBalancesFrequency"`
+	SubmitPricesEnabled      bool     `json:"submitPricesEnabled"`
+	SubmitPricesFrequency    uint64   `json:"submitPricesFrequency"`
+	MinimumNodeFee           float64  `json:"minimumNodeFee"`
+	TargetNodeFee            float64  `json:"targetNodeFee"`
+	MaximumNodeFee           float64  `json:"maximumNodeFee"`
+	NodeFeeDemandRange       *big.Int `json:"nodeFeeDemandRange"`
+	TargetRethCollateralRate float64  `json:"targetRethCollateralRate"`
+}
+type NodeSettings struct {
+	RegistrationEnabled     bool    `json:"registrationEnabled"`
+	DepositEnabled          bool    `json:"depositEnabled"`
+	MinimumPerMinipoolStake float64 `json:"minimumPerMinipoolStake"`
+	MaximumPerMinipoolStake float64 `json:"maximumPerMinipoolStake"`
+}
+type RewardsSettings struct {
+	ClaimerPercs      map[string]float64 `json:"claimerPercs"`
+	ClaimersPercTotal float64            `json:"claimersPercTotal"`
+	ClaimIntervalTime uint64             `json:"claimIntervalTime"`
+}
+
+// Trusted node DAO settings
+type TrustedNodeSettings struct {
+	Members   MembersSettings         `json:"members"`
+	Minipool  TrustedMinipoolSettings `json:"minipool"`
+	Proposals ProposalsSettings       `json:"proposals"`
+}
+type MembersSettings struct {
+	Quorum                 float64  `json:"quorum"`
+	RPLBond                *big.Int `json:"rplBond"`
+	MinipoolUnbondedMax    uint64   `json:"minipoolUnbondedMax"`
+	MinipoolUnbondedMinFee uint64   `json:"minipoolUnbondedMinFee"`
+	ChallengeCooldown      uint64   `json:"challengeCooldown"`
+	ChallengeWindow        uint64   `json:"challengeWindow"`
+	ChallengeCost          *big.Int `json:"challengeCost"`
+}
+type TrustedMinipoolSettings struct {
+	ScrubPeriod         uint64 `json:"scrubPeriod"`
+	ScrubPenaltyEnabled bool   `json:"scrubPenaltyEnabled"`
+}
+type ProposalsSettings struct {
+	CooldownTime  uint64 `json:"cooldownTime"`
+	VoteTime      uint64 `json:"voteTime"`
+	VoteDelayTime uint64 `json:"voteDelayTime"`
+	ExecuteTime   uint64 `json:"executeTime"`
+	ActionTime    uint64 `json:"actionTime"`
+}
+
+// A setting changed between two snapshots
+type SettingChange struct {
+	Name     string      `json:"name"`
+	OldValue interface{} `json:"oldValue"`
+	NewValue interface{} `json:"newValue"`
+}
+
+// Format a setting change for display
+func (c SettingChange) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Name, c.OldValue, c.NewValue)
+}
+
+// Get a snapshot of all protocol and trusted node DAO settings
+// Pass opts with a block number to get the settings at that block
+func GetAllSettings(rp *rocketpool.RocketPool, opts *bind.CallOpts) (Settings, error) {
+
+	// Data
+	var wg errgroup.Group
+	var settings Settings
+	if opts != nil && opts.BlockNumber != nil {
+		settings.BlockNumber = new(big.Int).Set(opts.BlockNumber)
+	}
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		settings.Protocol.Auction, err = getAuctionSettings(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.Protocol.Deposit, err = getDepositSettings(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.Protocol.Inflation, err = getInflationSettings(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.Protocol.Minipool, err = getMinipoolSettings(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.Protocol.Network, err = getNetworkSettings(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.Protocol.Node, err = getNodeSettings(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.Protocol.Rewards, err = getRewardsSettings(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.TrustedNode.Members, err = getMembersSettings(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.TrustedNode.Minipool, err = getTrustedMinipoolSettings(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.TrustedNode.Proposals, err = getProposalsSettings(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return Settings{}, err
+	}
+
+	// Return
+	return settings, nil
+
+}
+
+// Get the settings which differ between two snapshots, sorted by name
+// Setting names are the dotted JSON field paths (e.g. "protocol.auction.lotDuration")
+func Diff(a, b Settings) []SettingChange {
+	changes := []SettingChange{}
+	diffValues("protocol", reflect.ValueOf(a.Protocol), reflect.ValueOf(b.Protocol), &changes)
+	diffValues("trustedNode", reflect.ValueOf(a.TrustedNode), reflect.ValueOf(b.TrustedNode), &changes)
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// Add the differences between two setting values to a list of changes
+func diffValues(name string, a, b reflect.Value, changes *[]SettingChange) {
+	switch a.Kind() {
+	case reflect.Struct:
+		for fi := 0; fi < a.NumField(); fi++ {
+			fieldName := strings.Split(a.Type().Field(fi).Tag.Get("json"), ",")[0]
+			diffValues(name+"."+fieldName, a.Field(fi), b.Field(fi), changes)
+		}
+	case reflect.Map:
+		keys := map[string]bool{}
+		for _, key := range a.MapKeys() {
+			keys[key.String()] = true
+		}
+		for _, key := range b.MapKeys() {
+			keys[key.String()] = true
+		}
+		for key := range keys {
+			keyValue := reflect.ValueOf(key)
+			aValue, bValue := a.MapIndex(keyValue), b.MapIndex(keyValue)
+			switch {
+			case !aValue.IsValid():
+				*changes = append(*changes, SettingChange{Name: name + "." + key, OldValue: nil, NewValue: bValue.Interface()})
+			case !bValue.IsValid():
+				*changes = append(*changes, SettingChange{Name: name + "." + key, OldValue: aValue.Interface(), NewValue: nil})
+			default:
+				diffValues(name+"."+key, aValue, bValue, changes)
+			}
+		}
+	default:
+		aValue, bValue := a.Interface(), b.Interface()
+		if !settingValuesEqual(aValue, bValue) {
+			*changes = append(*changes, SettingChange{Name: name, OldValue: aValue, NewValue: bValue})
+		}
+	}
+}
+
+// Check whether two setting values are equal
+func settingValuesEqual(a, b interface{}) bool {
+	if aInt, ok := a.(*big.Int); ok {
+		bInt := b.(*big.Int)
+		if aInt == nil || bInt == nil {
+			return aInt == bInt
+		}
+		return aInt.Cmp(bInt) == 0
+	}
+	return a == b
+}
+
+// Get protocol auction settings
+func getAuctionSettings(rp *rocketpool.RocketPool, opts *bind.CallOpts) (AuctionSettings, error) {
+
+	// Data
+	var wg errgroup.Group
+	var settings AuctionSettings
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		settings.CreateLotEnabled, err = protocol.GetCreateLotEnabled(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.BidOnLotEnabled, err = protocol.GetBidOnLotEnabled(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.LotMinimumEthValue, err = protocol.GetLotMinimumEthValue(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.LotMaximumEthValue, err = protocol.GetLotMaximumEthValue(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.LotDuration, err = protocol.GetLotDuration(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.LotStartingPriceRatio, err = protocol.GetLotStartingPriceRatio(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.LotReservePriceRatio, err = protocol.GetLotReservePriceRatio(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return AuctionSettings{}, err
+	}
+
+	// Return
+	return settings, nil
+
+}
+
+// Get protocol deposit settings
+func getDepositSettings(rp *rocketpool.RocketPool, opts *bind.CallOpts) (DepositSettings, error) {
+
+	// Data
+	var wg errgroup.Group
+	var settings DepositSettings
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		settings.DepositEnabled, err = protocol.GetDepositEnabled(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.AssignDepositsEnabled, err = protocol.GetAssignDepositsEnabled(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.MinimumDeposit, err = protocol.GetMinimumDeposit(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.MaximumDepositPoolSize, err = protocol.GetMaximumDepositPoolSize(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.MaximumDepositAssignments, err = protocol.GetMaximumDepositAssignments(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return DepositSettings{}, err
+	}
+
+	// Return
+	return settings, nil
+
+}
+
+// Get protocol inflation settings
+func getInflationSettings(rp *rocketpool.RocketPool, opts *bind.CallOpts) (InflationSettings, error) {
+
+	// Data
+	var wg errgroup.Group
+	var settings InflationSettings
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		settings.IntervalRate, err = protocol.GetInflationIntervalRate(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.StartTime, err = protocol.GetInflationStartTime(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return InflationSettings{}, err
+	}
+
+	// Return
+	return settings, nil
+
+}
+
+// Get protocol minipool settings
+func getMinipoolSettings(rp *rocketpool.RocketPool, opts *bind.CallOpts) (MinipoolSettings, error) {
+
+	// Data
+	var wg errgroup.Group
+	var settings MinipoolSettings
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		settings.LaunchBalance, err = protocol.GetMinipoolLaunchBalance(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.FullDepositNodeAmount, err = protocol.GetMinipoolFullDepositNodeAmount(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.HalfDepositNodeAmount, err = protocol.GetMinipoolHalfDepositNodeAmount(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.EmptyDepositNodeAmount, err = protocol.GetMinipoolEmptyDepositNodeAmount(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.FullDepositUserAmount, err = protocol.GetMinipoolFullDepositUserAmount(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.HalfDepositUserAmount, err = protocol.GetMinipoolHalfDepositUserAmount(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.EmptyDepositUserAmount, err = protocol.GetMinipoolEmptyDepositUserAmount(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.SubmitWithdrawableEnabled, err = protocol.GetMinipoolSubmitWithdrawableEnabled(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.LaunchTimeout, err = protocol.GetMinipoolLaunchTimeout(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return MinipoolSettings{}, err
+	}
+
+	// Return
+	return settings, nil
+
+}
+
+// Get protocol network settings
+func getNetworkSettings(rp *rocketpool.RocketPool, opts *bind.CallOpts) (NetworkSettings, error) {
+
+	// Data
+	var wg errgroup.Group
+	var settings NetworkSettings
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		settings.NodeConsensusThreshold, err = protocol.GetNodeConsensusThreshold(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.SubmitBalancesEnabled, err = protocol.GetSubmitBalancesEnabled(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.SubmitBalancesFrequency, err = protocol.GetSubmitBalancesFrequency(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.SubmitPricesEnabled, err = protocol.GetSubmitPricesEnabled(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.SubmitPricesFrequency, err = protocol.GetSubmitPricesFrequency(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.MinimumNodeFee, err = protocol.GetMinimumNodeFee(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.TargetNodeFee, err = protocol.GetTargetNodeFee(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.MaximumNodeFee, err = protocol.GetMaximumNodeFee(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.NodeFeeDemandRange, err = protocol.GetNodeFeeDemandRange(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.TargetRethCollateralRate, err = protocol.GetTargetRethCollateralRate(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return NetworkSettings{}, err
+	}
+
+	// Return
+	return settings, nil
+
+}
+
+// Get protocol node settings
+func getNodeSettings(rp *rocketpool.RocketPool, opts *bind.CallOpts) (NodeSettings, error) {
+
+	// Data
+	var wg errgroup.Group
+	var settings NodeSettings
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		settings.RegistrationEnabled, err = protocol.GetNodeRegistrationEnabled(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.DepositEnabled, err = protocol.GetNodeDepositEnabled(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.MinimumPerMinipoolStake, err = protocol.GetMinimumPerMinipoolStake(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.MaximumPerMinipoolStake, err = protocol.GetMaximumPerMinipoolStake(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return NodeSettings{}, err
+	}
+
+	// Return
+	return settings, nil
+
+}
+
+// Get protocol rewards settings
+func getRewardsSettings(rp *rocketpool.RocketPool, opts *bind.CallOpts) (RewardsSettings, error) {
+
+	// Data
+	var wg errgroup.Group
+	var settings RewardsSettings
+	claimerPercs := make([]float64, len(RewardsClaimerContractNames))
+
+	// Load data
+	for ci, contractName := range RewardsClaimerContractNames {
+		ci, contractName := ci, contractName
+		wg.Go(func() error {
+			var err error
+			claimerPercs[ci], err = protocol.GetRewardsClaimerPerc(rp, contractName, opts)
+			return err
+		})
+	}
+	wg.Go(func() error {
+		var err error
+		settings.ClaimersPercTotal, err = protocol.GetRewardsClaimersPercTotal(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.ClaimIntervalTime, err = protocol.GetRewardsClaimIntervalTime(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return RewardsSettings{}, err
+	}
+
+	// Return
+	settings.ClaimerPercs = make(map[string]float64, len(RewardsClaimerContractNames))
+	for ci, contractName := range RewardsClaimerContractNames {
+		settings.ClaimerPercs[contractName] = claimerPercs[ci]
+	}
+	return settings, nil
+
+}
+
+// Get trusted node members settings
+func getMembersSettings(rp *rocketpool.RocketPool, opts *bind.CallOpts) (MembersSettings, error) {
+
+	// Data
+	var wg errgroup.Group
+	var settings MembersSettings
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		settings.Quorum, err = trustednode.GetQuorum(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.RPLBond, err = trustednode.GetRPLBond(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.MinipoolUnbondedMax, err = trustednode.GetMinipoolUnbondedMax(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.MinipoolUnbondedMinFee, err = trustednode.GetMinipoolUnbondedMinFee(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.ChallengeCooldown, err = trustednode.GetChallengeCooldown(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.ChallengeWindow, err = trustednode.GetChallengeWindow(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.ChallengeCost, err = trustednode.GetChallengeCost(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return MembersSettings{}, err
+	}
+
+	// Return
+	return settings, nil
+
+}
+
+// Get trusted node minipool settings
+func getTrustedMinipoolSettings(rp *rocketpool.RocketPool, opts *bind.CallOpts) (TrustedMinipoolSettings, error) {
+
+	// Data
+	var wg errgroup.Group
+	var settings TrustedMinipoolSettings
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		settings.ScrubPeriod, err = trustednode.GetScrubPeriod(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.ScrubPenaltyEnabled, err = trustednode.GetScrubPenaltyEnabled(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return TrustedMinipoolSettings{}, err
+	}
+
+	// Return
+	return settings, nil
+
+}
+
+// Get trusted node proposals settings
+func getProposalsSettings(rp *rocketpool.RocketPool, opts *bind.CallOpts) (ProposalsSettings, error) {
+
+	// Data
+	var wg errgroup.Group
+	var settings ProposalsSettings
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		settings.CooldownTime, err = trustednode.GetProposalCooldownTime(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.VoteTime, err = trustednode.GetProposalVoteTime(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.VoteDelayTime, err = trustednode.GetProposalVoteDelayTime(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.ExecuteTime, err = trustednode.GetProposalExecuteTime(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		settings.ActionTime, err = trustednode.GetProposalActionTime(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return ProposalsSettings{}, err
+	}
+
+	// Return
+	return settings, nil
+
+}
@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+)
+
+// RocketStorage key namespaces
+const (
+	DeployBlockNamespace          = "deploy.block"
+	ContractAddressNamespace      = "contract.address"
+	ContractABINamespace          = "contract.abi"
+	ContractExistsNamespace       = "contract.exists"
+	ContractNameNamespace         = "contract.name"
+	NodeExistsNamespace           = "node.exists"
+	NodeTimezoneLocationNamespace = "node.timezone.location"
+	MinipoolExistsNamespace       = "minipool.exists"
+	MinipoolPubkeyNamespace       = "minipool.pubkey"
+	ValidatorMinipoolNamespace    = "validator.minipool"
+	TrustedNodeDAONamespace       = "dao.trustednodes."
+	DAOProposalNamespace          = "dao.proposal."
+	ProtocolSettingsNamespace     = "dao.protocol.setting."
+	TrustedNodeSettingsNamespace  = "dao.trustednodes.setting."
+)
+
+// Derive a RocketStorage key from its parts, matching Solidity's keccak256(abi.encodePacked(...))
+// Supported parts are string, []byte, common.Address, common.Hash, bool, uint64 and *big.Int (as uint256)
+func Key(parts ...interface{}) (common.Hash, error) {
+	data := [][]byte{}
+	for _, part := range parts {
+		packed, err := packKeyPart(part)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		data = append(data, packed)
+	}
+	return crypto.Keccak256Hash(data...), nil
+}
+
+// Get the packed encoding of a key part
+func packKeyPart(part interface{}) ([]byte, error) {
+	switch value := part.(type) {
+	case string:
+		return []byte(value), nil
+	case []byte:
+		return value, nil
+	case common.Address:
+		return value.Bytes(), nil
+	case common.Hash:
+		return value.Bytes(), nil
+	case bool:
+		if value {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case uint64:
+		return common.BigToHash(new(big.Int).SetUint64(value)).Bytes(), nil
+	case *big.Int:
+		return common.BigToHash(value).Bytes(), nil
+	default:
+		return nil, fmt.Errorf("Unsupported RocketStorage key part type %T", part)
+	}
+}
+
+// Derive a RocketStorage key from packed parts
+func packedKey(parts ...[]byte) common.Hash {
+	return crypto.Keccak256Hash(parts...)
+}
+
+// Get the key of the block Rocket Pool was deployed at
+func DeployBlockKey() common.Hash {
+	return packedKey([]byte(DeployBlockNamespace))
+}
+
+// Get the key of a network contract's address
+func ContractAddressKey(contractName string) common.Hash {
+	return packedKey([]byte(ContractAddressNamespace), []byte(contractName))
+}
+
+// Get the key of a network contract's ABI
+func ContractABIKey(contractName string) common.Hash {
+	return packedKey([]byte(ContractABINamespace), []byte(contractName))
+}
+
+// Get the key of whether an address is a network contract
+func ContractExistsKey(contractAddress common.Address) common.Hash {
+	return packedKey([]byte(ContractExistsNamespace), contractAddress.Bytes())
+}
+
+// Get the key of a network contract's name
+func ContractNameKey(contractAddress common.Address) common.Hash {
+	return packedKey([]byte(ContractNameNamespace), contractAddress.Bytes())
+}
+
+// Get the key of whether a node is registered
+func NodeExistsKey(nodeAddress common.Address) common.Hash {
+	return packedKey([]byte(NodeExistsNamespace), nodeAddress.Bytes())
+}
+
+// Get the key of a node's timezone location
+func NodeTimezoneLocationKey(nodeAddress common.Address) common.Hash {
+	return packedKey([]byte(NodeTimezoneLocationNamespace), nodeAddress.Bytes())
+}
+
+// Get the key of whether a minipool exists
+func MinipoolExistsKey(minipoolAddress common.Address) common.Hash {
+	return packedKey([]byte(MinipoolExistsNamespace), minipoolAddress.Bytes())
+}
+
+// Get the key of a minipool's validator pubkey
+func MinipoolPubkeyKey(minipoolAddress common.Address) common.Hash {
+	return packedKey([]byte(MinipoolPubkeyNamespace), minipoolAddress.Bytes())
+}
+
+// Get the key of the minipool for a validator pubkey
+func ValidatorMinipoolKey(pubkey []byte) common.Hash {
+	return packedKey([]byte(ValidatorMinipoolNamespace), pubkey)
+}
+
+// Get the key of a trusted node DAO member field (e.g. "member", "member.id", "member.url")
+func TrustedNodeMemberKey(field string, memberAddress common.Address) common.Hash {
+	return packedKey([]byte(TrustedNodeDAONamespace), []byte(field), memberAddress.Bytes())
+}
+
+// Get the key of a DAO proposal field (e.g. "dao", "proposer", "message", "start", "end")
+func DAOProposalKey(field string, proposalId uint64) common.Hash {
+	return packedKey([]byte(DAOProposalNamespace), []byte(field), common.BigToHash(new(big.Int).SetUint64(proposalId)).Bytes())
+}
+
+// Get the namespace of a protocol DAO settings contract (e.g. "deposit", "minipool")
+func ProtocolSettingsNamespaceKey(settingsName string) common.Hash {
+	return packedKey([]byte(ProtocolSettingsNamespace), []byte(settingsName))
+}
+
+// Get the namespace of a trusted node DAO settings contract (e.g. "members", "proposals")
+func TrustedNodeSettingsNamespaceKey(settingsName string) common.Hash {
+	return packedKey([]byte(TrustedNodeSettingsNamespace), []byte(settingsName))
+}
+
+// Get the key of a setting within a settings namespace
+func SettingKey(namespace common.Hash, settingPath string) common.Hash {
+	return packedKey(namespace.Bytes(), []byte(settingPath))
+}
+
+// Get the key of a protocol DAO setting (e.g. "minipool", "minipool.launch.timeout")
+func ProtocolSettingKey(settingsName, settingPath string) common.Hash {
+	return SettingKey(ProtocolSettingsNamespaceKey(settingsName), settingPath)
+}
+
+// Get the key of a trusted node DAO setting (e.g. "members", "members.quorum")
+func TrustedNodeSettingKey(settingsName, settingPath string) common.Hash {
+	return SettingKey(TrustedNodeSettingsNamespaceKey(settingsName), settingPath)
+}
+
+// Get an address value from RocketStorage
+func GetAddress(rp *rocketpool.RocketPool, key common.Hash, opts *bind.CallOpts) (common.Address, error) {
+	value := new(common.Address)
+	if err := rp.RocketStorageContract.Call(opts, value, "getAddress", key); err != nil {
+		return common.Address{}, fmt.Errorf("Could not get storage address %s: %w", key.Hex(), err)
+	}
+	return *value, nil
+}
+
+// Get a uint value from RocketStorage
+func GetUint(rp *rocketpool.RocketPool, key common.Hash, opts *bind.CallOpts) (*big.Int, error) {
+	value := new(*big.Int)
+	if err := rp.RocketStorageContract.Call(opts, value, "getUint", key); err != nil {
+		return nil, fmt.Errorf("Could not get storage uint %s: %w", key.Hex(), err)
+	}
+	return *value, nil
+}
+
+// Get an int value from RocketStorage
+func GetInt(rp *rocketpool.RocketPool, key common.Hash, opts *bind.CallOpts) (*big.Int, error) {
+	value := new(*big.Int)
+	if err := rp.RocketStorageContract.Call(opts, value, "getInt", key); err != nil {
+		return nil, fmt.Errorf("Could not get storage int %s: %w", key.Hex(), err)
+	}
+	return *value, nil
+}
+
+// Get a bool value from RocketStorage
+func GetBool(rp *rocketpool.RocketPool, key common.Hash, opts *bind.CallOpts) (bool, error) {
+	value := new(bool)
+	if err := rp.RocketStorageContract.Call(opts, value, "getBool", key); err != nil {
+		return false, fmt.Errorf("Could not get storage bool %s: %w", key.Hex(), err)
+	}
+	return *value, nil
+}
+
+// Get a string value from RocketStorage
+func GetString(rp *rocketpool.RocketPool, key common.Hash, opts *bind.CallOpts) (string, error) {
+	value := new(string)
+	if err := rp.RocketStorageContract.Call(opts, value, "getString", key); err != nil {
+		return "", fmt.Errorf("Could not get storage string %s: %w", key.Hex(), err)
+	}
+	return *value, nil
+}
+
+// Get a bytes value from RocketStorage
+func GetBytes(rp *rocketpool.RocketPool, key common.Hash, opts *bind.CallOpts) ([]byte, error) {
+	value := new([]byte)
+	if err := rp.RocketStorageContract.Call(opts, value, "getBytes", key); err != nil {
+		return nil, fmt.Errorf("Could not get storage bytes %s: %w", key.Hex(), err)
+	}
+	return *value, nil
+}
+
+// Get a bytes32 value from RocketStorage
+func GetBytes32(rp *rocketpool.RocketPool, key common.Hash, opts *bind.CallOpts) (common.Hash, error) {
+	value := new([32]byte)
+	if err := rp.RocketStorageContract.Call(opts, value, "getBytes32", key); err != nil {
+		return common.Hash{}, fmt.Errorf("Could not get storage bytes32 %s: %w", key.Hex(), err)
+	}
+	return common.Hash(*value), nil
+}
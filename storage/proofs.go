@@ -0,0 +1,354 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+)
+
+// The storage slots of the RocketStorage contract's mappings
+type StorageLayout struct {
+	StringSlot                   uint64
+	BytesSlot                    uint64
+	UintSlot                     uint64
+	IntSlot                      uint64
+	AddressSlot                  uint64
+	BoolSlot                     uint64
+	Bytes32Slot                  uint64
+	WithdrawalAddressSlot        uint64
+	PendingWithdrawalAddressSlot uint64
+}
+
+// The RocketStorage v1 storage layout
+var DefaultStorageLayout = StorageLayout{
+	StringSlot:                   0,
+	BytesSlot:                    1,
+	UintSlot:                     2,
+	IntSlot:                      3,
+	AddressSlot:                  4,
+	BoolSlot:                     5,
+	Bytes32Slot:                  6,
+	WithdrawalAddressSlot:        7,
+	PendingWithdrawalAddressSlot: 8,
+}
+
+// An eth_getProof response
+type AccountProof struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []StorageProof  `json:"storageProof"`
+}
+
+// An eth_getProof storage slot proof
+type StorageProof struct {
+	Key   string          `json:"key"`
+	Value *hexutil.Big    `json:"value"`
+	Proof []hexutil.Bytes `json:"proof"`
+}
+
+// An RPC client supporting eth_getProof (e.g. *rpc.Client)
+type ProofClient interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// Reads RocketStorage values via eth_getProof, verifying them against a trusted state root
+type VerifiedStorageReader struct {
+	Client         ProofClient
+	StorageAddress common.Address
+	BlockNumber    *big.Int
+	StateRoot      common.Hash
+	Layout         StorageLayout
+}
+
+// Create a new verified storage reader for a block with a trusted state root
+func NewVerifiedStorageReader(rp *rocketpool.RocketPool, client ProofClient, blockNumber *big.Int, stateRoot common.Hash) *VerifiedStorageReader {
+	return &VerifiedStorageReader{
+		Client:         client,
+		StorageAddress: *rp.RocketStorageContract.Address,
+		BlockNumber:    blockNumber,
+		StateRoot:      stateRoot,
+		Layout:         DefaultStorageLayout,
+	}
+}
+
+// Get the storage slot of a mapping value
+func MappingSlot(key common.Hash, mappingSlot uint64) common.Hash {
+	return crypto.Keccak256Hash(key.Bytes(), common.BigToHash(new(big.Int).SetUint64(mappingSlot)).Bytes())
+}
+
+// Get the storage slot of an address-keyed mapping value
+func AddressMappingSlot(key common.Address, mappingSlot uint64) common.Hash {
+	return MappingSlot(common.BytesToHash(key.Bytes()), mappingSlot)
+}
+
+// Verify an account proof and its storage proofs against a state root
+// Returns the proven value of each storage slot
+func VerifyAccountProof(stateRoot common.Hash, proof *AccountProof) (map[common.Hash]common.Hash, error) {
+
+	// Verify the account
+	accountRlp, err := trie.VerifyProof(stateRoot, crypto.Keccak256(proof.Address.Bytes()), proofDB(proof.AccountProof))
+	if err != nil {
+		return nil, fmt.Errorf("Could not verify account proof for %s: %w", proof.Address.Hex(), err)
+	}
+	if accountRlp == nil {
+		return nil, fmt.Errorf("Account %s does not exist at state root %s", proof.Address.Hex(), stateRoot.Hex())
+	}
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(accountRlp, &account); err != nil {
+		return nil, fmt.Errorf("Could not decode account %s: %w", proof.Address.Hex(), err)
+	}
+	if account.Root != proof.StorageHash {
+		return nil, fmt.Errorf("Account %s storage hash %s does not match proven storage root %s", proof.Address.Hex(), proof.StorageHash.Hex(), account.Root.Hex())
+	}
+	if uint64(proof.Nonce) != account.Nonce || (proof.Balance != nil && proof.Balance.ToInt().Cmp(account.Balance) != 0) || !bytes.Equal(proof.CodeHash.Bytes(), account.CodeHash) {
+		return nil, fmt.Errorf("Account %s data does not match its proof", proof.Address.Hex())
+	}
+
+	// Verify storage slots
+	values := make(map[common.Hash]common.Hash, len(proof.StorageProof))
+	for _, storageProof := range proof.StorageProof {
+		slot, value, err := VerifyStorageProof(account.Root, storageProof)
+		if err != nil {
+			return nil, err
+		}
+		values[slot] = value
+	}
+
+	// Return
+	return values, nil
+
+}
+
+// Verify a storage slot proof against a storage root
+// Returns an error if the claimed value does not match the proven value
+func VerifyStorageProof(storageRoot common.Hash, proof StorageProof) (common.Hash, common.Hash, error) {
+
+	// Get slot
+	keyBytes, err := hexutil.Decode(proof.Key)
+	if err != nil {
+		keyInt, ok := new(big.Int).SetString(proof.Key, 0)
+		if !ok {
+			return common.Hash{}, common.Hash{}, fmt.Errorf("Invalid storage proof key %s", proof.Key)
+		}
+		keyBytes = keyInt.Bytes()
+	}
+	slot := common.BytesToHash(keyBytes)
+
+	// Verify the slot value
+	valueRlp, err := trie.VerifyProof(storageRoot, crypto.Keccak256(slot.Bytes()), proofDB(proof.Proof))
+	if err != nil {
+		return common.Hash{}, common.Hash{}, fmt.Errorf("Could not verify storage proof for slot %s: %w", slot.Hex(), err)
+	}
+	value := common.Hash{}
+	if valueRlp != nil {
+		var valueBytes []byte
+		if err := rlp.DecodeBytes(valueRlp, &valueBytes); err != nil {
+			return common.Hash{}, common.Hash{}, fmt.Errorf("Could not decode storage slot %s: %w", slot.Hex(), err)
+		}
+		value = common.BytesToHash(valueBytes)
+	}
+
+	// Check the claimed value
+	claimed := big.NewInt(0)
+	if proof.Value != nil {
+		claimed = proof.Value.ToInt()
+	}
+	if claimed.Cmp(value.Big()) != 0 {
+		return common.Hash{}, common.Hash{}, fmt.Errorf("Storage slot %s value %s does not match proven value %s", slot.Hex(), claimed.String(), value.Big().String())
+	}
+
+	// Return
+	return slot, value, nil
+
+}
+
+// Get and verify the values of RocketStorage slots
+func (r *VerifiedStorageReader) GetSlots(slots []common.Hash) (map[common.Hash]common.Hash, error) {
+
+	// Get proof
+	keys := make([]string, len(slots))
+	for si, slot := range slots {
+		keys[si] = slot.Hex()
+	}
+	blockNumber := "latest"
+	if r.BlockNumber != nil {
+		blockNumber = hexutil.EncodeBig(r.BlockNumber)
+	}
+	var proof AccountProof
+	if err := r.Client.CallContext(context.Background(), &proof, "eth_getProof", r.StorageAddress, keys, blockNumber); err != nil {
+		return nil, fmt.Errorf("Could not get RocketStorage proof: %w", err)
+	}
+	if proof.Address != r.StorageAddress {
+		return nil, fmt.Errorf("Proof is for account %s instead of RocketStorage %s", proof.Address.Hex(), r.StorageAddress.Hex())
+	}
+
+	// Verify proof
+	values, err := VerifyAccountProof(r.StateRoot, &proof)
+	if err != nil {
+		return nil, err
+	}
+	for _, slot := range slots {
+		if _, ok := values[slot]; !ok {
+			return nil, fmt.Errorf("Proof does not include RocketStorage slot %s", slot.Hex())
+		}
+	}
+
+	// Return
+	return values, nil
+
+}
+
+// Get and verify the value of a RocketStorage slot
+func (r *VerifiedStorageReader) GetSlot(slot common.Hash) (common.Hash, error) {
+	values, err := r.GetSlots([]common.Hash{slot})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return values[slot], nil
+}
+
+// Get a verified address value
+func (r *VerifiedStorageReader) GetAddress(key common.Hash) (common.Address, error) {
+	value, err := r.GetSlot(MappingSlot(key, r.Layout.AddressSlot))
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(value.Bytes()), nil
+}
+
+// Get a verified uint value
+func (r *VerifiedStorageReader) GetUint(key common.Hash) (*big.Int, error) {
+	value, err := r.GetSlot(MappingSlot(key, r.Layout.UintSlot))
+	if err != nil {
+		return nil, err
+	}
+	return value.Big(), nil
+}
+
+// Get a verified int value
+func (r *VerifiedStorageReader) GetInt(key common.Hash) (*big.Int, error) {
+	value, err := r.GetSlot(MappingSlot(key, r.Layout.IntSlot))
+	if err != nil {
+		return nil, err
+	}
+	intValue := value.Big()
+	if intValue.Bit(255) == 1 {
+		intValue.Sub(intValue, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	return intValue, nil
+}
+
+// Get a verified bool value
+func (r *VerifiedStorageReader) GetBool(key common.Hash) (bool, error) {
+	value, err := r.GetSlot(MappingSlot(key, r.Layout.BoolSlot))
+	if err != nil {
+		return false, err
+	}
+	return (value != common.Hash{}), nil
+}
+
+// Get a verified bytes32 value
+func (r *VerifiedStorageReader) GetBytes32(key common.Hash) (common.Hash, error) {
+	return r.GetSlot(MappingSlot(key, r.Layout.Bytes32Slot))
+}
+
+// Get a verified string value
+func (r *VerifiedStorageReader) GetString(key common.Hash) (string, error) {
+	value, err := r.getDynamicBytes(MappingSlot(key, r.Layout.StringSlot))
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+// Get a verified bytes value
+func (r *VerifiedStorageReader) GetBytes(key common.Hash) ([]byte, error) {
+	return r.getDynamicBytes(MappingSlot(key, r.Layout.BytesSlot))
+}
+
+// Get a node's verified withdrawal address
+// Returns the node address if no withdrawal address is set, matching RocketStorage.getNodeWithdrawalAddress
+func (r *VerifiedStorageReader) GetNodeWithdrawalAddress(nodeAddress common.Address) (common.Address, error) {
+	value, err := r.GetSlot(AddressMappingSlot(nodeAddress, r.Layout.WithdrawalAddressSlot))
+	if err != nil {
+		return common.Address{}, err
+	}
+	withdrawalAddress := common.BytesToAddress(value.Bytes())
+	if withdrawalAddress == (common.Address{}) {
+		return nodeAddress, nil
+	}
+	return withdrawalAddress, nil
+}
+
+// Get a node's verified pending withdrawal address
+func (r *VerifiedStorageReader) GetNodePendingWithdrawalAddress(nodeAddress common.Address) (common.Address, error) {
+	value, err := r.GetSlot(AddressMappingSlot(nodeAddress, r.Layout.PendingWithdrawalAddressSlot))
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(value.Bytes()), nil
+}
+
+// Get a verified network contract address
+func (r *VerifiedStorageReader) GetContractAddress(contractName string) (common.Address, error) {
+	return r.GetAddress(ContractAddressKey(contractName))
+}
+
+// Get a verified dynamic bytes value stored at a slot using the Solidity storage encoding
+func (r *VerifiedStorageReader) getDynamicBytes(slot common.Hash) ([]byte, error) {
+
+	// Get the header slot
+	header, err := r.GetSlot(slot)
+	if err != nil {
+		return nil, err
+	}
+
+	// Short values are stored in the header slot with length * 2 in the lowest byte
+	if header[31]&1 == 0 {
+		length := int(header[31] / 2)
+		return common.CopyBytes(header[:length]), nil
+	}
+
+	// Long values store length * 2 + 1 in the header slot and data from keccak256(slot)
+	length := new(big.Int).Rsh(header.Big(), 1).Uint64()
+	dataStart := crypto.Keccak256Hash(slot.Bytes()).Big()
+	slots := make([]common.Hash, (length+31)/32)
+	for si := range slots {
+		slots[si] = common.BigToHash(new(big.Int).Add(dataStart, big.NewInt(int64(si))))
+	}
+	values, err := r.GetSlots(slots)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, 0, len(slots)*32)
+	for _, dataSlot := range slots {
+		value := values[dataSlot]
+		data = append(data, value.Bytes()...)
+	}
+	return data[:length], nil
+
+}
+
+// Build a proof database from proof nodes
+func proofDB(nodes []hexutil.Bytes) *memorydb.Database {
+	db := memorydb.New()
+	for _, node := range nodes {
+		db.Put(crypto.Keccak256(node), node)
+	}
+	return db
+}
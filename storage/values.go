@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+)
+
+// Get the block Rocket Pool was deployed at
+func GetDeployBlock(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint64, error) {
+	deployBlock, err := GetUint(rp, DeployBlockKey(), opts)
+	if err != nil {
+		return 0, err
+	}
+	return deployBlock.Uint64(), nil
+}
+
+// Get a network contract's address
+func GetContractAddress(rp *rocketpool.RocketPool, contractName string, opts *bind.CallOpts) (common.Address, error) {
+	return GetAddress(rp, ContractAddressKey(contractName), opts)
+}
+
+// Get a network contract's ABI
+// Returns nil if no ABI is registered for the contract
+func GetContractABI(rp *rocketpool.RocketPool, contractName string, opts *bind.CallOpts) (*abi.ABI, error) {
+	abiEncoded, err := GetString(rp, ContractABIKey(contractName), opts)
+	if err != nil {
+		return nil, err
+	}
+	if abiEncoded == "" {
+		return nil, nil
+	}
+	contractAbi, err := rocketpool.DecodeAbi(abiEncoded)
+	if err != nil {
+		return nil, fmt.Errorf("Could not decode contract %s ABI: %w", contractName, err)
+	}
+	return contractAbi, nil
+}
+
+// Get whether an address is a network contract
+func GetContractExists(rp *rocketpool.RocketPool, contractAddress common.Address, opts *bind.CallOpts) (bool, error) {
+	return GetBool(rp, ContractExistsKey(contractAddress), opts)
+}
+
+// Get the name of the network contract at an address
+func GetContractName(rp *rocketpool.RocketPool, contractAddress common.Address, opts *bind.CallOpts) (string, error) {
+	return GetString(rp, ContractNameKey(contractAddress), opts)
+}
+
+// Get whether a node is registered
+func GetNodeExists(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (bool, error) {
+	return GetBool(rp, NodeExistsKey(nodeAddress), opts)
+}
+
+// Get a node's timezone location
+func GetNodeTimezoneLocation(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (string, error) {
+	return GetString(rp, NodeTimezoneLocationKey(nodeAddress), opts)
+}
+
+// Get whether a minipool exists
+func GetMinipoolExists(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.CallOpts) (bool, error) {
+	return GetBool(rp, MinipoolExistsKey(minipoolAddress), opts)
+}
+
+// Get a minipool's validator pubkey
+func GetMinipoolPubkey(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.CallOpts) ([]byte, error) {
+	return GetBytes(rp, MinipoolPubkeyKey(minipoolAddress), opts)
+}
+
+// Get the minipool for a validator pubkey
+func GetValidatorMinipool(rp *rocketpool.RocketPool, pubkey []byte, opts *bind.CallOpts) (common.Address, error) {
+	return GetAddress(rp, ValidatorMinipoolKey(pubkey), opts)
+}
+
+// Get whether an address is a trusted node DAO member
+func GetTrustedNodeMemberExists(rp *rocketpool.RocketPool, memberAddress common.Address, opts *bind.CallOpts) (bool, error) {
+	return GetBool(rp, TrustedNodeMemberKey("member", memberAddress), opts)
+}
+
+// Get a trusted node DAO member's ID
+func GetTrustedNodeMemberID(rp *rocketpool.RocketPool, memberAddress common.Address, opts *bind.CallOpts) (string, error) {
+	return GetString(rp, TrustedNodeMemberKey("member.id", memberAddress), opts)
+}
+
+// Get a trusted node DAO member's URL
+func GetTrustedNodeMemberURL(rp *rocketpool.RocketPool, memberAddress common.Address, opts *bind.CallOpts) (string, error) {
+	return GetString(rp, TrustedNodeMemberKey("member.url", memberAddress), opts)
+}
+
+// Get the name of the DAO a proposal was submitted to
+func GetDAOProposalDAO(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.CallOpts) (string, error) {
+	return GetString(rp, DAOProposalKey("dao", proposalId), opts)
+}
+
+// Get the address which submitted a DAO proposal
+func GetDAOProposalProposer(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.CallOpts) (common.Address, error) {
+	return GetAddress(rp, DAOProposalKey("proposer", proposalId), opts)
+}
+
+// Get a DAO proposal's message
+func GetDAOProposalMessage(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.CallOpts) (string, error) {
+	return GetString(rp, DAOProposalKey("message", proposalId), opts)
+}
+
+// Get a uint protocol DAO setting (e.g. "minipool", "minipool.launch.timeout")
+func GetProtocolSettingUint(rp *rocketpool.RocketPool, settingsName, settingPath string, opts *bind.CallOpts) (*big.Int, error) {
+	return GetUint(rp, ProtocolSettingKey(settingsName, settingPath), opts)
+}
+
+// Get a bool protocol DAO setting (e.g. "deposit", "deposit.enabled")
+func GetProtocolSettingBool(rp *rocketpool.RocketPool, settingsName, settingPath string, opts *bind.CallOpts) (bool, error) {
+	return GetBool(rp, ProtocolSettingKey(settingsName, settingPath), opts)
+}
+
+// Get a uint trusted node DAO setting (e.g. "members", "members.quorum")
+func GetTrustedNodeSettingUint(rp *rocketpool.RocketPool, settingsName, settingPath string, opts *bind.CallOpts) (*big.Int, error) {
+	return GetUint(rp, TrustedNodeSettingKey(settingsName, settingPath), opts)
+}
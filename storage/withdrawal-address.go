@@ -0,0 +1,219 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+)
+
+// The state of a withdrawal address change
+type WithdrawalAddressChangeState string
+
+const (
+	WithdrawalAddressChangeNone      WithdrawalAddressChangeState = "none"
+	WithdrawalAddressChangePending   WithdrawalAddressChangeState = "pending"
+	WithdrawalAddressChangeConfirmed WithdrawalAddressChangeState = "confirmed"
+)
+
+// A confirmed withdrawal address change
+type WithdrawalAddressChange struct {
+	NodeAddress       common.Address `json:"nodeAddress"`
+	WithdrawalAddress common.Address `json:"withdrawalAddress"`
+	Time              uint64         `json:"time"`
+	BlockNumber       uint64         `json:"blockNumber"`
+	TxHash            common.Hash    `json:"txHash"`
+}
+
+// A two-step change of a node's withdrawal address to a new address
+type WithdrawalAddressWorkflow struct {
+	RocketPool  *rocketpool.RocketPool
+	NodeAddress common.Address
+	NewAddress  common.Address
+}
+
+// Create a new withdrawal address change workflow
+func NewWithdrawalAddressWorkflow(rp *rocketpool.RocketPool, nodeAddress, newAddress common.Address) (*WithdrawalAddressWorkflow, error) {
+	if newAddress == (common.Address{}) {
+		return nil, errors.New("Withdrawal address cannot be the zero address")
+	}
+	return &WithdrawalAddressWorkflow{
+		RocketPool:  rp,
+		NodeAddress: nodeAddress,
+		NewAddress:  newAddress,
+	}, nil
+}
+
+// Get the state of the change
+func (w *WithdrawalAddressWorkflow) GetState(opts *bind.CallOpts) (WithdrawalAddressChangeState, error) {
+	withdrawalAddress, err := GetNodeWithdrawalAddress(w.RocketPool, w.NodeAddress, opts)
+	if err != nil {
+		return "", err
+	}
+	if withdrawalAddress == w.NewAddress {
+		return WithdrawalAddressChangeConfirmed, nil
+	}
+	pendingWithdrawalAddress, err := GetNodePendingWithdrawalAddress(w.RocketPool, w.NodeAddress, opts)
+	if err != nil {
+		return "", err
+	}
+	if pendingWithdrawalAddress == w.NewAddress {
+		return WithdrawalAddressChangePending, nil
+	}
+	return WithdrawalAddressChangeNone, nil
+}
+
+// Check that an account can set the new withdrawal address
+func (w *WithdrawalAddressWorkflow) CheckSet(sender common.Address, opts *bind.CallOpts) error {
+
+	// Check sender
+	withdrawalAddress, err := GetNodeWithdrawalAddress(w.RocketPool, w.NodeAddress, opts)
+	if err != nil {
+		return err
+	}
+	if withdrawalAddress == w.NewAddress {
+		return fmt.Errorf("Node %s withdrawal address is already %s", w.NodeAddress.Hex(), w.NewAddress.Hex())
+	}
+	if sender != withdrawalAddress {
+		return fmt.Errorf("Withdrawal address can only be set by the current withdrawal address %s", withdrawalAddress.Hex())
+	}
+
+	// Check the new address can receive ETH
+	canReceive, err := GetAddressCanReceiveETH(w.RocketPool, w.NewAddress, sender, opts)
+	if err != nil {
+		return err
+	}
+	if !canReceive {
+		return fmt.Errorf("Withdrawal address %s is a contract that cannot receive ETH", w.NewAddress.Hex())
+	}
+
+	// Return
+	return nil
+
+}
+
+// Set the new withdrawal address, confirming it immediately if confirm is true
+func (w *WithdrawalAddressWorkflow) Set(confirm bool, opts *bind.TransactOpts) (common.Hash, error) {
+	if err := w.CheckSet(opts.From, nil); err != nil {
+		return common.Hash{}, err
+	}
+	return SetWithdrawalAddress(w.RocketPool, w.NodeAddress, w.NewAddress, confirm, opts)
+}
+
+// Check that an account can confirm the new withdrawal address
+func (w *WithdrawalAddressWorkflow) CheckConfirm(sender common.Address, opts *bind.CallOpts) error {
+	state, err := w.GetState(opts)
+	if err != nil {
+		return err
+	}
+	switch state {
+	case WithdrawalAddressChangeNone:
+		return fmt.Errorf("Withdrawal address %s is not pending for node %s", w.NewAddress.Hex(), w.NodeAddress.Hex())
+	case WithdrawalAddressChangeConfirmed:
+		return fmt.Errorf("Withdrawal address %s is already confirmed for node %s", w.NewAddress.Hex(), w.NodeAddress.Hex())
+	}
+	if sender != w.NewAddress {
+		return fmt.Errorf("Withdrawal address can only be confirmed by the pending withdrawal address %s", w.NewAddress.Hex())
+	}
+	return nil
+}
+
+// Confirm the new withdrawal address
+func (w *WithdrawalAddressWorkflow) Confirm(opts *bind.TransactOpts) (common.Hash, error) {
+	if err := w.CheckConfirm(opts.From, nil); err != nil {
+		return common.Hash{}, err
+	}
+	return ConfirmWithdrawalAddress(w.RocketPool, w.NodeAddress, opts)
+}
+
+// Check whether an address can receive ETH transfers
+// Externally owned accounts always can; contracts must accept a plain transfer from the sender
+func GetAddressCanReceiveETH(rp *rocketpool.RocketPool, address, sender common.Address, opts *bind.CallOpts) (bool, error) {
+
+	// Get call options block number
+	var blockNumber *big.Int
+	if opts != nil {
+		blockNumber = opts.BlockNumber
+	}
+
+	// Check for contract code
+	code, err := rp.Client.CodeAt(context.Background(), address, blockNumber)
+	if err != nil {
+		return false, fmt.Errorf("Could not get code at %s: %w", address.Hex(), err)
+	}
+	if len(code) == 0 {
+		return true, nil
+	}
+
+	// Simulate a transfer
+	_, err = rp.Client.CallContract(context.Background(), ethereum.CallMsg{
+		From:  sender,
+		To:    &address,
+		Value: big.NewInt(1),
+	}, blockNumber)
+	if err == nil {
+		return true, nil
+	}
+	if isExecutionError(err) {
+		return false, nil
+	}
+	return false, fmt.Errorf("Could not simulate a transfer to %s: %w", address.Hex(), err)
+
+}
+
+// Check whether a call error was raised by the EVM executing the call, rather than by the node or transport
+func isExecutionError(err error) bool {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	for _, reason := range []string{"revert", "vm exception", "out of gas", "invalid opcode"} {
+		if strings.Contains(message, reason) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the confirmed withdrawal address changes for a node since fromBlock
+func GetWithdrawalAddressHistory(rp *rocketpool.RocketPool, nodeAddress common.Address, fromBlock *big.Int, intervalSize *big.Int) ([]WithdrawalAddressChange, error) {
+
+	// Get the event logs
+	event := rp.RocketStorageContract.ABI.Events["NodeWithdrawalAddressSet"]
+	addressFilter := []common.Address{*rp.RocketStorageContract.Address}
+	topicFilter := [][]common.Hash{{event.ID}, {nodeAddress.Hash()}}
+	logs, err := eth.GetLogs(rp, addressFilter, topicFilter, intervalSize, fromBlock, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the events
+	changes := make([]WithdrawalAddressChange, len(logs))
+	for li, log := range logs {
+		values := make(map[string]interface{})
+		if err := event.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return nil, fmt.Errorf("Could not decode NodeWithdrawalAddressSet event: %w", err)
+		}
+		changes[li] = WithdrawalAddressChange{
+			NodeAddress:       common.BytesToAddress(log.Topics[1].Bytes()),
+			WithdrawalAddress: common.BytesToAddress(log.Topics[2].Bytes()),
+			Time:              values["time"].(*big.Int).Uint64(),
+			BlockNumber:       log.BlockNumber,
+			TxHash:            log.TxHash,
+		}
+	}
+
+	// Return
+	return changes, nil
+
+}
@@ -0,0 +1,185 @@
+package auction
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/PatriceVignola/rocketpool-go/auction"
+	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
+	"github.com/PatriceVignola/rocketpool-go/settings/trustednode"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+
+	auctionutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/auction"
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+	nodeutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/node"
+)
+
+func TestLotModel(t *testing.T) {
+
+	// Lot of 1000 RPL falling from 1 ETH to 0.5 ETH per RPL over 100 blocks
+	model := auction.LotModel{
+		StartBlock:     1000,
+		EndBlock:       1100,
+		StartPrice:     eth.EthToWei(1),
+		ReservePrice:   eth.EthToWei(0.5),
+		TotalRPLAmount: eth.EthToWei(1000),
+		TotalBidAmount: big.NewInt(0),
+	}
+
+	// Check price curve
+	if price := model.PriceAtBlock(900); price.Cmp(eth.EthToWei(1)) != 0 {
+		t.Errorf("Incorrect price before start %s", price.String())
+	}
+	if price := model.PriceAtBlock(1050); price.Cmp(eth.EthToWei(0.875)) != 0 {
+		t.Errorf("Incorrect price at midpoint %s", price.String())
+	}
+	if price := model.PriceAtBlock(1200); price.Cmp(eth.EthToWei(0.5)) != 0 {
+		t.Errorf("Incorrect price after end %s", price.String())
+	}
+	curve := model.PriceCurve(25)
+	if len(curve) != 5 || curve[4].Block != 1100 {
+		t.Errorf("Incorrect price curve length %d", len(curve))
+	}
+	for pi := 1; pi < len(curve); pi++ {
+		if curve[pi].Price.Cmp(curve[pi-1].Price) > 0 {
+			t.Errorf("Price curve increases at block %d", curve[pi].Block)
+		}
+	}
+
+	// Check target price block
+	if block, ok := model.BlockAtPrice(eth.EthToWei(0.875)); !ok || block != 1050 {
+		t.Errorf("Incorrect target price block %d", block)
+	}
+	if _, ok := model.BlockAtPrice(eth.EthToWei(0.4)); ok {
+		t.Error("Found a block below the reserve price")
+	}
+
+	// Simulate a bid which doesn't clear the lot
+	simulation := model.SimulateBid(eth.EthToWei(100), 1050)
+	if simulation.AcceptedAmount.Cmp(eth.EthToWei(100)) != 0 || simulation.RefundAmount.Sign() != 0 {
+		t.Errorf("Incorrect accepted bid amount %s", simulation.AcceptedAmount.String())
+	}
+	if simulation.ClearsLot {
+		t.Error("Incorrect lot cleared status")
+	}
+	if simulation.ClearingPrice.Cmp(eth.EthToWei(0.5)) != 0 {
+		t.Errorf("Incorrect clearing price %s", simulation.ClearingPrice.String())
+	}
+	if simulation.RPLAmount.Cmp(eth.EthToWei(200)) != 0 {
+		t.Errorf("Incorrect RPL amount %s", simulation.RPLAmount.String())
+	}
+	if minRplAmount := new(big.Int).Div(new(big.Int).Mul(eth.EthToWei(1), eth.EthToWei(100)), eth.EthToWei(0.875)); simulation.MinRPLAmount.Cmp(minRplAmount) != 0 {
+		t.Errorf("Incorrect minimum RPL amount %s", simulation.MinRPLAmount.String())
+	}
+
+	// Simulate a bid which clears the lot
+	simulation = model.SimulateBid(eth.EthToWei(1000), 1050)
+	if simulation.AcceptedAmount.Cmp(eth.EthToWei(875)) != 0 || simulation.RefundAmount.Cmp(eth.EthToWei(125)) != 0 {
+		t.Errorf("Incorrect capped bid amounts %s / %s", simulation.AcceptedAmount.String(), simulation.RefundAmount.String())
+	}
+	if !simulation.ClearsLot {
+		t.Error("Incorrect capped lot cleared status")
+	}
+	if simulation.RPLAmount.Cmp(eth.EthToWei(1000)) != 0 {
+		t.Errorf("Incorrect capped RPL amount %s", simulation.RPLAmount.String())
+	}
+
+	// Check bidding strategy
+	strategy := auction.BidStrategy{TargetPrice: eth.EthToWei(0.9), BidAmount: eth.EthToWei(10)}
+	if strategy.ShouldBid(model, 1010) {
+		t.Error("Strategy bid above its target price")
+	}
+	if !strategy.ShouldBid(model, 1050) {
+		t.Error("Strategy did not bid below its target price")
+	}
+	model.TotalBidAmount = eth.EthToWei(875)
+	if strategy.ShouldBid(model, 1050) {
+		t.Error("Strategy bid on a cleared lot")
+	}
+
+}
+
+func TestPlaceBidAtTarget(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Register nodes
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount1); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount2); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount3); err != nil {
+		t.Fatal(err)
+	}
+
+	// Disable min commission rate for unbonded pools
+	if _, err := trustednode.BootstrapMinipoolUnbondedMinFee(rp, uint64(0), ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Mint slashed RPL to auction contract & create a lot
+	if err := auctionutils.CreateSlashedRPL(t, rp, ownerAccount, trustedNodeAccount1, trustedNodeAccount2, userAccount1); err != nil {
+		t.Fatal(err)
+	}
+	lotIndex, _, err := auction.CreateLot(rp, userAccount1.GetTransactor())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Check the modelled price curve against the contract
+	lot, err := auction.GetLotDetails(rp, lotIndex, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	model := auction.NewLotModel(lot)
+	for _, block := range []uint64{lot.StartBlock, (lot.StartBlock + lot.EndBlock) / 2, lot.EndBlock - 1, lot.EndBlock} {
+		if price, err := auction.GetLotPriceAtBlock(rp, lotIndex, block, nil); err != nil {
+			t.Error(err)
+		} else if price.Cmp(model.PriceAtBlock(block)) != 0 {
+			t.Errorf("Incorrect modelled price %s at block %d, expected %s", model.PriceAtBlock(block).String(), block, price.String())
+		}
+	}
+
+	// Check that bids aren't placed above the target price
+	strategy := auction.BidStrategy{LotIndex: lotIndex, TargetPrice: big.NewInt(1), BidAmount: eth.EthToWei(1)}
+	if hash, err := auction.PlaceBidAtTarget(rp, strategy, userAccount1.GetTransactor()); err != nil {
+		t.Error(err)
+	} else if hash != nil {
+		t.Error("Bid was placed above the target price")
+	}
+
+	// Check that bids aren't placed while bidding is disabled
+	strategy.TargetPrice = lot.StartPrice
+	if _, err := protocol.BootstrapBidOnLotEnabled(rp, false, ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := auction.PlaceBidAtTarget(rp, strategy, userAccount1.GetTransactor()); err != auction.ErrBiddingDisabled {
+		t.Errorf("Incorrect disabled bidding error %v", err)
+	}
+	if _, err := protocol.BootstrapBidOnLotEnabled(rp, true, ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Place bid at target & check bid amount
+	if hash, err := auction.PlaceBidAtTarget(rp, strategy, userAccount1.GetTransactor()); err != nil {
+		t.Fatal(err)
+	} else if hash == nil {
+		t.Fatal("Bid was not placed below the target price")
+	}
+	if bidAmount, err := auction.GetLotAddressBidAmount(rp, lotIndex, userAccount1.Address, nil); err != nil {
+		t.Error(err)
+	} else if bidAmount.Cmp(eth.EthToWei(1)) != 0 {
+		t.Errorf("Incorrect lot address bid amount %s", bidAmount.String())
+	}
+
+}
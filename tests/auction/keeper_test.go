@@ -0,0 +1,157 @@
+package auction
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/PatriceVignola/rocketpool-go/auction"
+	"github.com/PatriceVignola/rocketpool-go/network"
+	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
+	"github.com/PatriceVignola/rocketpool-go/settings/trustednode"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+
+	auctionutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/auction"
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+	nodeutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/node"
+)
+
+func TestPlanKeeperActions(t *testing.T) {
+
+	// Auction state
+	data := auction.KeeperData{
+		Lots: []auction.LotDetails{
+			{Index: 0, Exists: true, EndBlock: 50, Cleared: true, AddressBidAmount: eth.EthToWei(1), RemainingRPLAmount: eth.EthToWei(0)},
+			{Index: 1, Exists: true, EndBlock: 50, Cleared: true, AddressBidAmount: eth.EthToWei(0), RemainingRPLAmount: eth.EthToWei(10)},
+			{Index: 2, Exists: true, EndBlock: 50, Cleared: true, AddressBidAmount: eth.EthToWei(2), RemainingRPLAmount: eth.EthToWei(10), RPLRecovered: true},
+			{Index: 3, Exists: true, EndBlock: 150, Cleared: false, AddressBidAmount: eth.EthToWei(1), RemainingRPLAmount: eth.EthToWei(10)},
+			{Index: 4, Exists: true, EndBlock: 150, Cleared: true, AddressBidAmount: eth.EthToWei(1), RemainingRPLAmount: eth.EthToWei(10)},
+		},
+		RemainingRPLBalance: eth.EthToWei(100),
+		RPLPrice:            eth.EthToWei(0.01),
+		LotMinimumEthValue:  eth.EthToWei(1),
+		CreateLotEnabled:    true,
+		CurrentBlock:        100,
+	}
+
+	// Check actions
+	actions := auction.PlanKeeperActions(data)
+	if len(actions.ClaimLots) != 3 || actions.ClaimLots[0] != 0 || actions.ClaimLots[1] != 2 || actions.ClaimLots[2] != 4 {
+		t.Errorf("Incorrect claim lots %v", actions.ClaimLots)
+	}
+	if len(actions.RecoverLots) != 1 || actions.RecoverLots[0] != 1 {
+		t.Errorf("Incorrect recover lots %v", actions.RecoverLots)
+	}
+	if !actions.CreateLot {
+		t.Error("Incorrect create lot status")
+	}
+
+	// Check that RPL is recovered from a lot which cleared early once it reaches its end block
+	data.CurrentBlock = 150
+	if actions := auction.PlanKeeperActions(data); len(actions.RecoverLots) != 2 || actions.RecoverLots[1] != 4 {
+		t.Errorf("Incorrect recover lots at end block %v", actions.RecoverLots)
+	}
+	data.CurrentBlock = 100
+
+	// Check that lots aren't created below the minimum value or while disabled
+	data.RPLPrice = eth.EthToWei(0.009)
+	if actions := auction.PlanKeeperActions(data); actions.CreateLot {
+		t.Error("Created a lot below the minimum lot value")
+	}
+	data.RPLPrice = eth.EthToWei(0.01)
+	data.CreateLotEnabled = false
+	if actions := auction.PlanKeeperActions(data); actions.CreateLot {
+		t.Error("Created a lot while lot creation is disabled")
+	}
+
+}
+
+func TestRunKeeper(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Register nodes
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount1); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount2); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount3); err != nil {
+		t.Fatal(err)
+	}
+
+	// Disable min commission rate for unbonded pools
+	if _, err := trustednode.BootstrapMinipoolUnbondedMinFee(rp, uint64(0), ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Set network parameters
+	if _, err := network.SubmitPrices(rp, 1, eth.EthToWei(1), eth.EthToWei(24), trustedNodeAccount1.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := network.SubmitPrices(rp, 1, eth.EthToWei(1), eth.EthToWei(24), trustedNodeAccount2.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := protocol.BootstrapLotMaximumEthValue(rp, eth.EthToWei(10), ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := protocol.BootstrapLotDuration(rp, 5, ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Mint slashed RPL to auction contract
+	if err := auctionutils.CreateSlashedRPL(t, rp, ownerAccount, trustedNodeAccount1, trustedNodeAccount2, userAccount1); err != nil {
+		t.Fatal(err)
+	}
+
+	// Run keeper & check that a lot was created
+	result, err := auction.RunKeeper(rp, userAccount1.GetTransactor())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.CreatedLot || result.CreatedLotIndex != 0 {
+		t.Fatalf("Incorrect created lot %t %d", result.CreatedLot, result.CreatedLotIndex)
+	}
+	if len(result.Actions.ClaimLots) != 0 || len(result.Actions.RecoverLots) != 0 {
+		t.Errorf("Incorrect initial keeper actions %v / %v", result.Actions.ClaimLots, result.Actions.RecoverLots)
+	}
+
+	// Place bid on lot & mine blocks until it hits reserve price
+	bidOpts := userAccount1.GetTransactor()
+	bidOpts.Value = eth.EthToWei(1)
+	if _, err := auction.PlaceBid(rp, result.CreatedLotIndex, bidOpts); err != nil {
+		t.Fatal(err)
+	}
+	if err := evm.MineBlocks(5); err != nil {
+		t.Fatal(err)
+	}
+
+	// Run keeper & check that the bid was claimed and unclaimed RPL recovered
+	result, err = auction.RunKeeper(rp, userAccount1.GetTransactor())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := result.ClaimHashes[0]; !ok {
+		t.Errorf("Bid on lot was not claimed %v", result.Actions.ClaimLots)
+	}
+	if _, ok := result.RecoverHashes[0]; !ok {
+		t.Errorf("Unclaimed RPL was not recovered from lot %v", result.Actions.RecoverLots)
+	}
+	if len(result.ClaimErrors) != 0 || len(result.RecoverErrors) != 0 {
+		t.Errorf("Incorrect keeper errors %v / %v", result.ClaimErrors, result.RecoverErrors)
+	}
+	if lot, err := auction.GetLotDetailsWithBids(rp, 0, userAccount1.Address, nil); err != nil {
+		t.Error(err)
+	} else if lot.AddressBidAmount.Cmp(big.NewInt(0)) != 0 || !lot.RPLRecovered {
+		t.Errorf("Incorrect lot state after keeper run: bid amount %s, RPL recovered %t", lot.AddressBidAmount.String(), lot.RPLRecovered)
+	}
+
+}
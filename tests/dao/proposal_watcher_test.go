@@ -0,0 +1,62 @@
+package dao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PatriceVignola/rocketpool-go/dao"
+	rptypes "github.com/PatriceVignola/rocketpool-go/types"
+)
+
+func TestProposalWatcher(t *testing.T) {
+
+	// Create watcher
+	watcher := dao.NewProposalWatcher(nil, "", []time.Duration{24 * time.Hour, time.Hour})
+	proposal := dao.ProposalDetails{
+		ID:         1,
+		DAO:        "rocketDAONodeTrustedProposals",
+		StartTime:  100000,
+		EndTime:    200000,
+		ExpiryTime: 300000,
+		State:      rptypes.Pending,
+	}
+
+	// Check new proposal event
+	events := watcher.UpdateProposals([]dao.ProposalDetails{proposal}, 0)
+	if len(events) != 1 || events[0].Type != dao.ProposalNewEvent || events[0].ProposalID != 1 {
+		t.Errorf("Incorrect new proposal events %v", events)
+	}
+	if transitions := watcher.GetUpcomingTransitions(); len(transitions) != 3 || transitions[0].Deadline != dao.VotingOpensDeadline || transitions[0].Time != 100000 {
+		t.Errorf("Incorrect upcoming transitions %v", transitions)
+	}
+
+	// Check voting opens reminders
+	if events := watcher.UpdateProposals([]dao.ProposalDetails{proposal}, 100000-23*3600); len(events) != 1 || events[0].Type != dao.ProposalReminderEvent || events[0].Deadline != dao.VotingOpensDeadline || events[0].TimeRemaining != 23*time.Hour {
+		t.Errorf("Incorrect 24h reminder events %v", events)
+	}
+	if events := watcher.UpdateProposals([]dao.ProposalDetails{proposal}, 100000-22*3600); len(events) != 0 {
+		t.Errorf("Incorrect repeated reminder events %v", events)
+	}
+	if events := watcher.UpdateProposals([]dao.ProposalDetails{proposal}, 100000-1800); len(events) != 1 || events[0].Deadline != dao.VotingOpensDeadline {
+		t.Errorf("Incorrect 1h reminder events %v", events)
+	}
+
+	// Check state change event
+	proposal.State = rptypes.Active
+	if events := watcher.UpdateProposals([]dao.ProposalDetails{proposal}, 100000); len(events) != 1 || events[0].Type != dao.ProposalStateChangedEvent || events[0].OldState != rptypes.Pending || events[0].State != rptypes.Active {
+		t.Errorf("Incorrect state change events %v", events)
+	}
+
+	// Check final proposals stop being tracked
+	proposal.State = rptypes.Executed
+	if events := watcher.UpdateProposals([]dao.ProposalDetails{proposal}, 150000); len(events) != 1 || events[0].State != rptypes.Executed {
+		t.Errorf("Incorrect final state events %v", events)
+	}
+	if transitions := watcher.GetUpcomingTransitions(); len(transitions) != 0 {
+		t.Errorf("Incorrect upcoming transitions %v", transitions)
+	}
+	if events := watcher.UpdateProposals([]dao.ProposalDetails{proposal}, 160000); len(events) != 0 {
+		t.Errorf("Incorrect untracked proposal events %v", events)
+	}
+
+}
@@ -198,6 +198,29 @@ func TestProposalDetails(t *testing.T) {
 	} else if daoProposals[0].ID != proposalId || daoProposals[1].ID != cancelledProposalId {
 		t.Error("Incorrect DAO proposal indexes")
 	}
+
+	// Get & check proposal details with decoded payload
+	if proposal, err := dao.GetProposalDetails(rp, proposalId, nil); err != nil {
+		t.Error(err)
+	} else if proposal.PayloadDecoded != nil {
+		t.Error("Proposal payload decoded without being requested")
+	}
+	if proposal, err := dao.GetProposalDetailsWithPayload(rp, proposalId, nil, nil); err != nil {
+		t.Error(err)
+	} else if proposal.PayloadDecoded == nil {
+		t.Error("Proposal payload not decoded")
+	} else {
+		if proposal.PayloadDecoded.Method != "proposalInvite" {
+			t.Errorf("Incorrect decoded proposal payload method %s", proposal.PayloadDecoded.Method)
+		}
+		if len(proposal.PayloadDecoded.Arguments) != 3 {
+			t.Errorf("Incorrect decoded proposal payload argument count %d", len(proposal.PayloadDecoded.Arguments))
+		}
+		expectedDescription := fmt.Sprintf("invite %s (%s) to join as a member with address %s", proposalMemberId, proposalMemberEmail, proposalMemberAddress.Hex())
+		if proposal.PayloadDecoded.Description != expectedDescription {
+			t.Errorf("Incorrect decoded proposal payload description %s", proposal.PayloadDecoded.Description)
+		}
+	}
 	if daoProposals, err := dao.GetDAOProposalsWithMember(rp, proposalDaoName, trustedNodeAccount1.Address, nil); err != nil {
 		t.Error(err)
 	} else if len(daoProposals) != 2 {
@@ -0,0 +1,80 @@
+package dao
+
+import (
+	"testing"
+
+	protocoldao "github.com/PatriceVignola/rocketpool-go/dao/protocol"
+	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+)
+
+func TestProtocolBootstrapMode(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Get & check initial bootstrap mode
+	if bootstrapMode, err := protocoldao.GetBootstrapMode(rp, nil); err != nil {
+		t.Fatal(err)
+	} else if !bootstrapMode {
+		t.Error("Incorrect initial bootstrap mode")
+	}
+
+	// Update setting in bootstrap mode
+	if _, err := protocoldao.BootstrapOrFailBool(rp, protocol.DepositSettingsContractName, "deposit.enabled", false, ownerAccount.GetTransactor()); err != nil {
+		t.Error(err)
+	} else if value, err := protocol.GetDepositEnabled(rp, nil); err != nil {
+		t.Error(err)
+	} else if value {
+		t.Error("Incorrect bootstrapped deposit enabled value")
+	}
+
+	// Check that no protocol DAO proposals were submitted
+	if proposals, err := protocoldao.GetProposals(rp, nil); err != nil {
+		t.Error(err)
+	} else if len(proposals) != 0 {
+		t.Errorf("Incorrect protocol DAO proposal count %d", len(proposals))
+	}
+
+	// Disable bootstrap mode
+	if _, err := protocoldao.BootstrapDisable(rp, ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Get & check updated bootstrap mode
+	if bootstrapMode, err := protocoldao.GetBootstrapMode(rp, nil); err != nil {
+		t.Error(err)
+	} else if bootstrapMode {
+		t.Error("Incorrect updated bootstrap mode")
+	}
+	if _, err := protocol.BootstrapDepositEnabled(rp, true, ownerAccount.GetTransactor()); err == nil {
+		t.Error("Bootstrapped a setting after bootstrap mode was disabled")
+	}
+
+	// Check that settings can't be updated by proposal, as the deployed contract has no proposal methods
+	if proposalsAbi, err := rp.GetABI(protocoldao.ProposalsDAOName); err != nil {
+		t.Error(err)
+	} else {
+		for _, method := range []string{"propose", "vote", "cancel", "execute"} {
+			if _, ok := proposalsAbi.Methods[method]; ok {
+				t.Errorf("Protocol DAO proposals contract has a %s method", method)
+			}
+		}
+	}
+	if _, err := protocoldao.BootstrapOrFailBool(rp, protocol.DepositSettingsContractName, "deposit.enabled", true, ownerAccount.GetTransactor()); err != protocoldao.ErrProposalsUnsupported {
+		t.Errorf("Incorrect protocol DAO proposal error %v", err)
+	} else if value, err := protocol.GetDepositEnabled(rp, nil); err != nil {
+		t.Error(err)
+	} else if value {
+		t.Error("Deposit enabled setting was updated after bootstrap mode was disabled")
+	}
+
+}
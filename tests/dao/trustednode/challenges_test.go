@@ -0,0 +1,197 @@
+package trustednode
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	trustednodedao "github.com/PatriceVignola/rocketpool-go/dao/trustednode"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+	nodeutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/node"
+)
+
+func TestChallengeMonitor(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Register nodes
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount1); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount2); err != nil {
+		t.Fatal(err)
+	}
+
+	// Create monitor
+	monitor := trustednodedao.NewChallengeMonitor(rp, trustedNodeAccount2.Address, nil, nil)
+
+	// Get & check initial status
+	if status, err := monitor.GetStatus(); err != nil {
+		t.Error(err)
+	} else if status.IsChallenged || status.Challenge != nil {
+		t.Error("Incorrect initial challenge status")
+	}
+
+	// Challenge member
+	if _, err := trustednodedao.MakeChallenge(rp, trustedNodeAccount2.Address, trustedNodeAccount1.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Get & check challenged status
+	if status, err := monitor.GetStatus(); err != nil {
+		t.Error(err)
+	} else if !status.IsChallenged {
+		t.Error("Incorrect challenged status")
+	} else if status.Challenge == nil {
+		t.Error("Current challenge not found")
+	} else if status.Challenge.ChallengerAddress != trustedNodeAccount1.Address {
+		t.Errorf("Incorrect challenger address %s", status.Challenge.ChallengerAddress.Hex())
+	} else if status.Challenge.Outcome != trustednodedao.ChallengePending {
+		t.Errorf("Incorrect challenge outcome %s", status.Challenge.Outcome)
+	} else if status.BlocksRemaining == 0 || status.CurrentBlock+status.BlocksRemaining != status.Challenge.DeadlineBlock {
+		t.Errorf("Incorrect blocks remaining %d", status.BlocksRemaining)
+	}
+
+	// Check that only the member can respond
+	if _, err := monitor.Respond(trustedNodeAccount1.GetTransactor()); err == nil {
+		t.Error("Responded to challenge from another account")
+	}
+
+	// Refute challenge
+	if hash, err := monitor.Respond(trustedNodeAccount2.GetTransactor()); err != nil {
+		t.Fatal(err)
+	} else if hash == nil {
+		t.Error("Challenge was not refuted")
+	}
+
+	// Get & check updated status
+	if status, err := monitor.GetStatus(); err != nil {
+		t.Error(err)
+	} else if status.IsChallenged {
+		t.Error("Incorrect updated challenged status")
+	}
+	if hash, err := monitor.Respond(trustedNodeAccount2.GetTransactor()); err != nil {
+		t.Error(err)
+	} else if hash != nil {
+		t.Error("Responded to a challenge which does not exist")
+	}
+
+	// Get & check challenge history
+	if challenges, err := trustednodedao.GetChallengeHistory(rp, nil, nil, nil); err != nil {
+		t.Error(err)
+	} else if len(challenges) != 1 {
+		t.Errorf("Incorrect challenge count %d", len(challenges))
+	} else {
+		challenge := challenges[0]
+		if challenge.ChallengedAddress != trustedNodeAccount2.Address {
+			t.Errorf("Incorrect challenged address %s", challenge.ChallengedAddress.Hex())
+		}
+		if challenge.DeciderAddress != trustedNodeAccount2.Address {
+			t.Errorf("Incorrect decider address %s", challenge.DeciderAddress.Hex())
+		}
+		if challenge.Outcome != trustednodedao.ChallengeRefuted {
+			t.Errorf("Incorrect challenge outcome %s", challenge.Outcome)
+		}
+		if challenge.DecidedBlock < challenge.BlockNumber {
+			t.Errorf("Incorrect challenge decided block %d", challenge.DecidedBlock)
+		}
+	}
+
+	// Get & check challenge history in intervals
+	intervalSize := big.NewInt(2)
+	if challenges, err := trustednodedao.GetChallengeHistory(rp, &trustedNodeAccount2.Address, nil, intervalSize); err != nil {
+		t.Error(err)
+	} else if len(challenges) != 1 || challenges[0].Outcome != trustednodedao.ChallengeRefuted {
+		t.Errorf("Incorrect interval challenge history %v", challenges)
+	}
+	if intervalSize.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("Challenge history modified its interval size %s", intervalSize.String())
+	}
+
+}
+
+func TestChallengeMonitorWatch(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Register nodes
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount1); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount2); err != nil {
+		t.Fatal(err)
+	}
+
+	// Start watching for challenges
+	monitor := trustednodedao.NewChallengeMonitor(rp, trustedNodeAccount2.Address, nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	refuted := make(chan common.Hash, 1)
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- monitor.Watch(ctx, 100*time.Millisecond, trustedNodeAccount2.GetTransactor(), func(hash common.Hash) {
+			refuted <- hash
+		})
+	}()
+
+	// Challenge member
+	if _, err := trustednodedao.MakeChallenge(rp, trustedNodeAccount2.Address, trustedNodeAccount1.GetTransactor()); err != nil {
+		cancel()
+		<-watchErr
+		t.Fatal(err)
+	}
+
+	// Wait for the watcher to refute the challenge
+	select {
+	case hash := <-refuted:
+		if hash == (common.Hash{}) {
+			t.Error("Incorrect challenge response transaction hash")
+		}
+	case err := <-watchErr:
+		cancel()
+		t.Fatal(err)
+	case <-time.After(10 * time.Second):
+		t.Error("Challenge was not refuted by the watcher")
+	}
+
+	// Stop watching
+	cancel()
+	if err := <-watchErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Incorrect watcher error %v", err)
+	}
+
+	// Check challenged status & challenge history
+	if isChallenged, err := trustednodedao.GetMemberIsChallenged(rp, trustedNodeAccount2.Address, nil); err != nil {
+		t.Error(err)
+	} else if isChallenged {
+		t.Error("Incorrect challenged status after watcher response")
+	}
+	if challenges, err := trustednodedao.GetChallengeHistory(rp, &trustedNodeAccount2.Address, nil, nil); err != nil {
+		t.Error(err)
+	} else if len(challenges) != 1 || challenges[0].Outcome != trustednodedao.ChallengeRefuted {
+		t.Errorf("Incorrect challenge history %v", challenges)
+	} else if challenges[0].DeciderAddress != trustedNodeAccount2.Address {
+		t.Errorf("Incorrect decider address %s", challenges[0].DeciderAddress.Hex())
+	}
+
+}
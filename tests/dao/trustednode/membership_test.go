@@ -0,0 +1,268 @@
+package trustednode
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	trustednodedao "github.com/PatriceVignola/rocketpool-go/dao/trustednode"
+	"github.com/PatriceVignola/rocketpool-go/node"
+	trustednodesettings "github.com/PatriceVignola/rocketpool-go/settings/trustednode"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/accounts"
+	daoutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/dao"
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+	nodeutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/node"
+	rplutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/tokens/rpl"
+)
+
+func TestMembershipStatusEvaluate(t *testing.T) {
+
+	// Base status
+	bond := eth.EthToWei(1750)
+	newStatus := func() trustednodedao.MembershipStatus {
+		return trustednodedao.MembershipStatus{
+			ActionTime:         100,
+			CurrentTime:        1000,
+			RPLBond:            bond,
+			RPLBalance:         bond,
+			RPLAllowance:       bond,
+			MemberCount:        4,
+			MinimumMemberCount: 3,
+		}
+	}
+
+	// Test cases
+	cases := []struct {
+		name      string
+		update    func(s *trustednodedao.MembershipStatus)
+		stage     trustednodedao.MembershipStage
+		nextStep  trustednodedao.MembershipStep
+		joinErr   error
+		leaveErr  error
+		remaining uint64
+	}{
+		{
+			name:     "not invited",
+			update:   func(s *trustednodedao.MembershipStatus) {},
+			stage:    trustednodedao.StageNotInvited,
+			nextStep: trustednodedao.StepProposeInvite,
+			joinErr:  trustednodedao.ErrNotInvited,
+			leaveErr: trustednodedao.ErrNotMember,
+		},
+		{
+			name:     "invite expired",
+			update:   func(s *trustednodedao.MembershipStatus) { s.InviteExecutedTime = 900 },
+			stage:    trustednodedao.StageInviteExpired,
+			nextStep: trustednodedao.StepProposeInvite,
+			joinErr:  trustednodedao.ErrActionWindowExpired,
+			leaveErr: trustednodedao.ErrNotMember,
+		},
+		{
+			name: "invited without RPL",
+			update: func(s *trustednodedao.MembershipStatus) {
+				s.InviteExecutedTime = 950
+				s.RPLBalance = big.NewInt(1)
+			},
+			stage:     trustednodedao.StageInvited,
+			nextStep:  trustednodedao.StepAcquireRPL,
+			joinErr:   trustednodedao.ErrInsufficientRPL,
+			leaveErr:  trustednodedao.ErrNotMember,
+			remaining: 50,
+		},
+		{
+			name: "invited without allowance",
+			update: func(s *trustednodedao.MembershipStatus) {
+				s.InviteExecutedTime = 950
+				s.RPLAllowance = big.NewInt(0)
+			},
+			stage:     trustednodedao.StageInvited,
+			nextStep:  trustednodedao.StepApproveRPL,
+			joinErr:   trustednodedao.ErrInsufficientRPLAllowance,
+			leaveErr:  trustednodedao.ErrNotMember,
+			remaining: 50,
+		},
+		{
+			name:      "invited",
+			update:    func(s *trustednodedao.MembershipStatus) { s.InviteExecutedTime = 950 },
+			stage:     trustednodedao.StageInvited,
+			nextStep:  trustednodedao.StepJoin,
+			leaveErr:  trustednodedao.ErrNotMember,
+			remaining: 50,
+		},
+		{
+			name:     "member",
+			update:   func(s *trustednodedao.MembershipStatus) { s.IsMember = true },
+			stage:    trustednodedao.StageMember,
+			nextStep: trustednodedao.StepProposeLeave,
+			joinErr:  trustednodedao.ErrAlreadyMember,
+			leaveErr: trustednodedao.ErrNoLeaveProposal,
+		},
+		{
+			name: "leave expired",
+			update: func(s *trustednodedao.MembershipStatus) {
+				s.IsMember = true
+				s.LeaveExecutedTime = 800
+			},
+			stage:    trustednodedao.StageLeaveExpired,
+			nextStep: trustednodedao.StepProposeLeave,
+			joinErr:  trustednodedao.ErrAlreadyMember,
+			leaveErr: trustednodedao.ErrActionWindowExpired,
+		},
+		{
+			name: "leave approved at minimum member count",
+			update: func(s *trustednodedao.MembershipStatus) {
+				s.IsMember = true
+				s.LeaveExecutedTime = 990
+				s.MemberCount = 3
+			},
+			stage:     trustednodedao.StageLeaveApproved,
+			nextStep:  trustednodedao.StepWaitForLeave,
+			joinErr:   trustednodedao.ErrAlreadyMember,
+			leaveErr:  trustednodedao.ErrMinimumMemberCount,
+			remaining: 90,
+		},
+		{
+			name: "leave approved",
+			update: func(s *trustednodedao.MembershipStatus) {
+				s.IsMember = true
+				s.LeaveExecutedTime = 990
+			},
+			stage:     trustednodedao.StageLeaveApproved,
+			nextStep:  trustednodedao.StepLeave,
+			joinErr:   trustednodedao.ErrAlreadyMember,
+			remaining: 90,
+		},
+	}
+
+	// Run test cases
+	for _, c := range cases {
+		status := newStatus()
+		c.update(&status)
+		status.Evaluate()
+		if status.Stage != c.stage {
+			t.Errorf("%s: incorrect stage %s", c.name, status.Stage)
+		}
+		if status.NextStep != c.nextStep {
+			t.Errorf("%s: incorrect next step %s", c.name, status.NextStep)
+		}
+		if err := status.CanJoin(); err != c.joinErr {
+			t.Errorf("%s: incorrect join check result %v", c.name, err)
+		}
+		if err := status.CanLeave(); err != c.leaveErr {
+			t.Errorf("%s: incorrect leave check result %v", c.name, err)
+		}
+		if remaining := status.GetActionTimeRemaining(); remaining != c.remaining {
+			t.Errorf("%s: incorrect action time remaining %d", c.name, remaining)
+		}
+	}
+
+	// Check leave proposal preconditions
+	status := newStatus()
+	if err := status.CanProposeLeave(); err != trustednodedao.ErrNotMember {
+		t.Errorf("Incorrect non-member propose leave check result %v", err)
+	}
+	status.IsMember = true
+	status.MemberCount = status.MinimumMemberCount
+	if err := status.CanProposeLeave(); err != trustednodedao.ErrMinimumMemberCount {
+		t.Errorf("Incorrect minimum member count propose leave check result %v", err)
+	}
+
+}
+
+func TestMembershipLifecycle(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Register nodes
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount1); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount2); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := node.RegisterNode(rp, "Australia/Brisbane", nodeAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Get & check initial status
+	if status, err := trustednodedao.GetMembershipStatus(rp, nodeAccount.Address, nil); err != nil {
+		t.Fatal(err)
+	} else if status.Stage != trustednodedao.StageNotInvited || status.NextStep != trustednodedao.StepProposeInvite {
+		t.Errorf("Incorrect initial membership stage %s and next step %s", status.Stage, status.NextStep)
+	}
+	if _, err := trustednodedao.JoinChecked(rp, nodeAccount.GetTransactor()); err == nil {
+		t.Error("Joined the trusted node DAO without an invite")
+	}
+
+	// Invite node
+	proposalId, _, err := trustednodedao.ProposeInviteMember(rp, "invite node", nodeAccount.Address, "node", "node@rocketpool.net", trustedNodeAccount1.GetTransactor())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := daoutils.PassAndExecuteProposal(rp, proposalId, []*accounts.Account{trustedNodeAccount1, trustedNodeAccount2}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Get & check invited status
+	if status, err := trustednodedao.GetMembershipStatus(rp, nodeAccount.Address, nil); err != nil {
+		t.Fatal(err)
+	} else if status.Stage != trustednodedao.StageInvited || status.NextStep != trustednodedao.StepAcquireRPL {
+		t.Errorf("Incorrect invited membership stage %s and next step %s", status.Stage, status.NextStep)
+	} else if status.GetActionTimeRemaining() == 0 {
+		t.Error("Incorrect invited action time remaining")
+	}
+
+	// Mint RPL bond & check status
+	rplBond, err := trustednodesettings.GetRPLBond(rp, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rplutils.MintRPL(rp, ownerAccount, nodeAccount, rplBond); err != nil {
+		t.Fatal(err)
+	}
+	if status, err := trustednodedao.GetMembershipStatus(rp, nodeAccount.Address, nil); err != nil {
+		t.Fatal(err)
+	} else if status.NextStep != trustednodedao.StepApproveRPL {
+		t.Errorf("Incorrect unapproved next step %s", status.NextStep)
+	}
+
+	// Approve RPL bond & check status
+	if _, err := trustednodedao.ApproveRPLBond(rp, nodeAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	if status, err := trustednodedao.GetMembershipStatus(rp, nodeAccount.Address, nil); err != nil {
+		t.Fatal(err)
+	} else if status.NextStep != trustednodedao.StepJoin {
+		t.Errorf("Incorrect bonded next step %s", status.NextStep)
+	}
+
+	// Join & check status
+	if _, err := trustednodedao.JoinChecked(rp, nodeAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	if status, err := trustednodedao.GetMembershipStatus(rp, nodeAccount.Address, nil); err != nil {
+		t.Fatal(err)
+	} else if status.Stage != trustednodedao.StageMember || !status.IsMember {
+		t.Errorf("Incorrect joined membership stage %s", status.Stage)
+	}
+
+	// Check leave preconditions
+	if _, err := trustednodedao.LeaveChecked(rp, common.Address{}, nodeAccount.GetTransactor()); err == nil {
+		t.Error("Left the trusted node DAO without a leave proposal")
+	}
+
+}
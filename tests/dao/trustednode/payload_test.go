@@ -0,0 +1,86 @@
+package trustednode
+
+import (
+	"testing"
+
+	"github.com/PatriceVignola/rocketpool-go/dao"
+	trustednodedao "github.com/PatriceVignola/rocketpool-go/dao/trustednode"
+	"github.com/PatriceVignola/rocketpool-go/settings"
+	trustednodesettings "github.com/PatriceVignola/rocketpool-go/settings/trustednode"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+	nodeutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/node"
+)
+
+func TestDecodeProposalPayload(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Set proposal cooldown & initial quorum
+	if _, err := trustednodesettings.BootstrapProposalCooldownTime(rp, 0, ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := trustednodesettings.BootstrapQuorum(rp, 0.51, ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Register trusted node
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount1); err != nil {
+		t.Fatal(err)
+	}
+
+	// Submit setting proposal
+	proposalId, _, err := trustednodesettings.ProposeQuorum(rp, 0.6, trustedNodeAccount1.GetTransactor())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Get & check decoded payload
+	payload, err := dao.GetProposalPayload(rp, proposalId, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded, err := dao.DecodeProposalPayload(rp, trustednodedao.ProposalsDAOName, payload, settings.FormatSettingValue, nil); err != nil {
+		t.Error(err)
+	} else if decoded.Method != "proposalSettingUint" {
+		t.Errorf("Incorrect proposal payload method %s", decoded.Method)
+	} else if len(decoded.Arguments) != 3 {
+		t.Errorf("Incorrect proposal payload argument count %d", len(decoded.Arguments))
+	} else if decoded.Arguments[1].ValueStr != trustednodesettings.QuorumSettingPath || decoded.Arguments[2].Type != "uint256" {
+		t.Errorf("Incorrect proposal payload arguments %v", decoded.Arguments)
+	} else if decoded.Description != "set rocketDAONodeTrustedSettingsMembers.members.quorum from 0.51 to 0.6" {
+		t.Errorf("Incorrect proposal payload description %s", decoded.Description)
+	}
+
+	// Check that setting values are described as raw integers without a formatter
+	if decoded, err := dao.DecodeProposalPayload(rp, trustednodedao.ProposalsDAOName, payload, nil, nil); err != nil {
+		t.Error(err)
+	} else if decoded.Description != "set rocketDAONodeTrustedSettingsMembers.members.quorum from "+eth.EthToWei(0.51).String()+" to "+eth.EthToWei(0.6).String() {
+		t.Errorf("Incorrect unformatted proposal payload description %s", decoded.Description)
+	}
+
+	// Submit kick proposal
+	proposalId, _, err = trustednodedao.ProposeKickMember(rp, "kick", trustedNodeAccount1.Address, eth.EthToWei(10), trustedNodeAccount1.GetTransactor())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Get & check decoded payload
+	if payload, err := dao.GetProposalPayload(rp, proposalId, nil); err != nil {
+		t.Error(err)
+	} else if decoded, err := dao.DecodeProposalPayload(rp, trustednodedao.ProposalsDAOName, payload, nil, nil); err != nil {
+		t.Error(err)
+	} else if decoded.Description != "kick member "+trustedNodeAccount1.Address.Hex()+" with a fine of 10.000000 RPL" {
+		t.Errorf("Incorrect proposal payload description %s", decoded.Description)
+	}
+
+}
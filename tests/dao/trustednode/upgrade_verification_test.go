@@ -0,0 +1,183 @@
+package trustednode
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	trustednodedao "github.com/PatriceVignola/rocketpool-go/dao/trustednode"
+	trustednodesettings "github.com/PatriceVignola/rocketpool-go/settings/trustednode"
+	"github.com/PatriceVignola/rocketpool-go/utils"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+	nodeutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/node"
+)
+
+const (
+	currentTestAbi       = `[{"name":"foo","type":"function","inputs":[],"outputs":[]},{"name":"bar","type":"function","inputs":[{"name":"a","type":"uint256"}],"outputs":[]},{"name":"Baz","type":"event","inputs":[]}]`
+	proposedTestAbi      = `[{"name":"foo","type":"function","inputs":[],"outputs":[]},{"name":"bar","type":"function","inputs":[{"name":"a","type":"address"}],"outputs":[]},{"name":"qux","type":"function","inputs":[],"outputs":[]}]`
+	testContractBytecode = "0x600a600c600039600a6000f3602a60005260206000f3" // Returns 42 from every call
+)
+
+func TestDiffABIs(t *testing.T) {
+
+	// Parse ABIs
+	currentAbi, err := abi.JSON(strings.NewReader(currentTestAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proposedAbi, err := abi.JSON(strings.NewReader(proposedTestAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Check diff
+	diff := trustednodedao.DiffABIs(&currentAbi, &proposedAbi)
+	if len(diff.AddedMethods) != 1 || !strings.Contains(diff.AddedMethods[0], "qux()") {
+		t.Errorf("Incorrect added methods %v", diff.AddedMethods)
+	}
+	if len(diff.RemovedMethods) != 0 {
+		t.Errorf("Incorrect removed methods %v", diff.RemovedMethods)
+	}
+	if len(diff.ChangedMethods) != 1 || !strings.Contains(diff.ChangedMethods[0], "bar(uint256 a)") || !strings.Contains(diff.ChangedMethods[0], "bar(address a)") {
+		t.Errorf("Incorrect changed methods %v", diff.ChangedMethods)
+	}
+	if len(diff.AddedEvents) != 0 || len(diff.RemovedEvents) != 1 || len(diff.ChangedEvents) != 0 {
+		t.Errorf("Incorrect event diff %v / %v / %v", diff.AddedEvents, diff.RemovedEvents, diff.ChangedEvents)
+	}
+
+	// Check diff against a missing ABI
+	diff = trustednodedao.DiffABIs(nil, &proposedAbi)
+	if len(diff.AddedMethods) != 3 || len(diff.RemovedMethods) != 0 {
+		t.Errorf("Incorrect new contract diff %v / %v", diff.AddedMethods, diff.RemovedMethods)
+	}
+
+	// Check verification report
+	code := []byte{0x60, 0x80, 0x60, 0x40}
+	codeHash := trustednodedao.GetCodeHash(code)
+	wrongCodeHash := common.HexToHash("0x01")
+	currentAddress := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	data := trustednodedao.UpgradeVerificationData{
+		ProposalID:       1,
+		UpgradeType:      trustednodedao.UpgradeContractType,
+		ContractName:     "rocketTest",
+		ContractAddress:  common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		ProposedABI:      &proposedAbi,
+		CurrentABI:       &currentAbi,
+		CurrentAddress:   &currentAddress,
+		Code:             code,
+		ExpectedCodeHash: &codeHash,
+	}
+	verification := trustednodedao.BuildUpgradeVerification(data)
+	if !verification.IsVerified() || !verification.HasCode || !verification.CodeHashMatches {
+		t.Errorf("Incorrect verification result: %s", verification.String())
+	}
+	if !strings.Contains(verification.String(), "Changed methods") {
+		t.Errorf("Incorrect verification report: %s", verification.String())
+	}
+
+	// Check failed verifications
+	data.ExpectedCodeHash = &wrongCodeHash
+	if verification := trustednodedao.BuildUpgradeVerification(data); verification.IsVerified() || verification.CodeHashMatches {
+		t.Error("Verified an upgrade with an incorrect bytecode hash")
+	}
+	data.ExpectedCodeHash = &codeHash
+	data.Code = []byte{}
+	if verification := trustednodedao.BuildUpgradeVerification(data); verification.IsVerified() || verification.HasCode {
+		t.Error("Verified an upgrade to an address without code")
+	}
+	data.Code = code
+	data.AddressInUse = true
+	data.AddressInUseBy = "rocketVault"
+	if verification := trustednodedao.BuildUpgradeVerification(data); verification.IsVerified() || !strings.Contains(verification.String(), "rocketVault") {
+		t.Error("Verified an upgrade to an address used by another network contract")
+	}
+	data.AddressInUse = false
+	data.AddressInUseBy = ""
+	data.UpgradeType = trustednodedao.AddContractType
+	if verification := trustednodedao.BuildUpgradeVerification(data); verification.IsVerified() {
+		t.Error("Verified adding a contract which already exists")
+	}
+
+}
+
+func TestVerifyUpgradeProposal(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Set proposal cooldown
+	if _, err := trustednodesettings.BootstrapProposalCooldownTime(rp, 0, ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Register nodes
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount1); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount2); err != nil {
+		t.Fatal(err)
+	}
+
+	// Deploy a contract which isn't a network contract & get its bytecode hash
+	contractAddress, tx, _, err := bind.DeployContract(ownerAccount.GetTransactor(), abi.ABI{}, common.FromHex(testContractBytecode), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := utils.WaitForTransaction(client, tx.Hash()); err != nil {
+		t.Fatal(err)
+	}
+	code, err := client.CodeAt(context.Background(), contractAddress, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codeHash := trustednodedao.GetCodeHash(code)
+
+	// Submit upgrade contract proposal
+	proposalContractAbi := "[{\"name\":\"foo\",\"type\":\"function\",\"inputs\":[],\"outputs\":[]}]"
+	proposalId, _, err := trustednodedao.ProposeUpgradeContract(rp, "upgrade rocketDepositPool", trustednodedao.UpgradeContractType, "rocketDepositPool", proposalContractAbi, contractAddress, trustedNodeAccount1.GetTransactor())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Verify & check report
+	if verification, err := trustednodedao.VerifyUpgradeProposal(rp, proposalId, &codeHash, nil); err != nil {
+		t.Error(err)
+	} else if !verification.IsVerified() {
+		t.Errorf("Incorrect verification result: %s", verification.String())
+	} else if verification.ContractName != "rocketDepositPool" || verification.ContractAddress != contractAddress {
+		t.Errorf("Incorrect decoded upgrade %s at %s", verification.ContractName, verification.ContractAddress.Hex())
+	} else if len(verification.ABIDiff.AddedMethods) != 1 || len(verification.ABIDiff.RemovedMethods) == 0 {
+		t.Errorf("Incorrect ABI diff %v / %v", verification.ABIDiff.AddedMethods, verification.ABIDiff.RemovedMethods)
+	}
+
+	// Verify an upgrade to another network contract's address
+	vaultAddress, err := rp.GetAddress("rocketVault")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verification, err := trustednodedao.VerifyUpgrade(rp, trustednodedao.UpgradeContractType, "rocketDepositPool", proposalContractAbi, *vaultAddress, nil, nil); err != nil {
+		t.Error(err)
+	} else if verification.IsVerified() || !strings.Contains(verification.String(), "rocketVault") {
+		t.Errorf("Incorrect network contract address verification result: %s", verification.String())
+	}
+
+	// Verify an upgrade to an address without code
+	if verification, err := trustednodedao.VerifyUpgrade(rp, trustednodedao.UpgradeContractType, "rocketDepositPool", proposalContractAbi, common.HexToAddress("0x1111111111111111111111111111111111111111"), nil, nil); err != nil {
+		t.Error(err)
+	} else if verification.IsVerified() || verification.HasCode {
+		t.Errorf("Incorrect codeless verification result: %s", verification.String())
+	}
+
+}
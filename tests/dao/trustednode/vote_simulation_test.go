@@ -0,0 +1,154 @@
+package trustednode
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/PatriceVignola/rocketpool-go/dao"
+	trustednodedao "github.com/PatriceVignola/rocketpool-go/dao/trustednode"
+	rptypes "github.com/PatriceVignola/rocketpool-go/types"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+	nodeutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/node"
+)
+
+func TestSimulateProposalVote(t *testing.T) {
+
+	// Members
+	member1 := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	member2 := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	member3 := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	member4 := common.HexToAddress("0x4444444444444444444444444444444444444444")
+	lateMember := common.HexToAddress("0x5555555555555555555555555555555555555555")
+	members := []trustednodedao.MemberDetails{
+		{Address: member1, JoinedTime: 100},
+		{Address: member2, JoinedTime: 100},
+		{Address: member3, JoinedTime: 100},
+		{Address: member4, JoinedTime: 100},
+		{Address: lateMember, JoinedTime: 1500},
+	}
+
+	// Active proposal with 1 of 2.55 votes (51% of 5 members)
+	data := trustednodedao.VoteSimulationData{
+		Proposal: dao.ProposalDetails{
+			ID:          1,
+			State:       rptypes.Active,
+			CreatedTime: 1000,
+			EndTime:     2000,
+			ExpiryTime:  3000,
+		},
+		Tally: dao.ProposalTally{
+			VotesRequired: eth.EthToWei(2.55),
+			VotesFor:      eth.EthToWei(1),
+			VotesAgainst:  eth.EthToWei(1),
+		},
+		Members:     members,
+		MemberVoted: map[common.Address]bool{member1: true, member2: true},
+		Quorum:      eth.EthToWei(0.51),
+		ExecuteTime: 1000,
+		CurrentTime: 1800,
+	}
+
+	// Check simulation
+	simulation := trustednodedao.SimulateProposalVote(data)
+	if simulation.VotesRequired.Cmp(eth.EthToWei(2.55)) != 0 {
+		t.Errorf("Incorrect votes required %s", simulation.VotesRequired.String())
+	}
+	if simulation.QuorumVotesRequired.Cmp(eth.EthToWei(2.55)) != 0 {
+		t.Errorf("Incorrect quorum votes required %s", simulation.QuorumVotesRequired.String())
+	}
+	if len(simulation.EligibleVoters) != 4 {
+		t.Errorf("Incorrect eligible voter count %d", len(simulation.EligibleVoters))
+	}
+	if len(simulation.RemainingVoters) != 2 || simulation.RemainingVoters[0] != member3 || simulation.RemainingVoters[1] != member4 {
+		t.Errorf("Incorrect remaining voters %v", simulation.RemainingVoters)
+	}
+	if simulation.AdditionalVotesRequired != 2 {
+		t.Errorf("Incorrect additional votes required %d", simulation.AdditionalVotesRequired)
+	}
+	if !simulation.CanPass || !simulation.CanBeDefeated {
+		t.Errorf("Incorrect reachable outcomes: can pass %t, can be defeated %t", simulation.CanPass, simulation.CanBeDefeated)
+	}
+	if len(simulation.ReachableStates) != 5 {
+		t.Errorf("Incorrect reachable states %v", simulation.ReachableStates)
+	}
+	if !simulation.ExpiresIfUnexecuted || simulation.ExpiryTime != 3000 || simulation.TimeUntilExpiry != 1200 {
+		t.Errorf("Incorrect expiry %t %d %d", simulation.ExpiresIfUnexecuted, simulation.ExpiryTime, simulation.TimeUntilExpiry)
+	}
+
+	// Check that the proposal can't pass once too few voters remain
+	data.MemberVoted[member3] = true
+	data.Tally.VotesAgainst = eth.EthToWei(2)
+	simulation = trustednodedao.SimulateProposalVote(data)
+	if simulation.CanPass || !simulation.CanBeDefeated || simulation.ExpiresIfUnexecuted {
+		t.Errorf("Incorrect unpassable outcomes: can pass %t, can be defeated %t, expires %t", simulation.CanPass, simulation.CanBeDefeated, simulation.ExpiresIfUnexecuted)
+	}
+	if len(simulation.ReachableStates) != 2 {
+		t.Errorf("Incorrect unpassable reachable states %v", simulation.ReachableStates)
+	}
+
+	// Check that a succeeded proposal can only be executed or expire
+	data.Proposal.State = rptypes.Succeeded
+	data.Tally.VotesFor = eth.EthToWei(3)
+	simulation = trustednodedao.SimulateProposalVote(data)
+	if simulation.AdditionalVotesRequired != 0 || !simulation.CanPass || simulation.CanBeDefeated {
+		t.Errorf("Incorrect succeeded outcomes: additional votes %d, can pass %t, can be defeated %t", simulation.AdditionalVotesRequired, simulation.CanPass, simulation.CanBeDefeated)
+	}
+	if len(simulation.ReachableStates) != 2 || simulation.ReachableStates[0] != rptypes.Executed || simulation.ReachableStates[1] != rptypes.Expired {
+		t.Errorf("Incorrect succeeded reachable states %v", simulation.ReachableStates)
+	}
+
+	// Check that an executed proposal has no reachable states
+	data.Proposal.State = rptypes.Executed
+	simulation = trustednodedao.SimulateProposalVote(data)
+	if len(simulation.ReachableStates) != 0 || simulation.ExpiresIfUnexecuted {
+		t.Errorf("Incorrect executed outcomes %v", simulation.ReachableStates)
+	}
+
+}
+
+func TestGetProposalVoteSimulation(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Register nodes
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount1); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount2); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount3); err != nil {
+		t.Fatal(err)
+	}
+
+	// Submit proposal
+	proposalId, _, err := trustednodedao.ProposeMemberLeave(rp, "member leave", trustedNodeAccount3.Address, trustedNodeAccount1.GetTransactor())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Get & check simulation
+	if simulation, err := trustednodedao.GetProposalVoteSimulation(rp, proposalId, nil); err != nil {
+		t.Error(err)
+	} else if simulation.ProposalID != proposalId {
+		t.Errorf("Incorrect simulation proposal ID %d", simulation.ProposalID)
+	} else if simulation.VotesFor.Sign() != 0 || simulation.VotesAgainst.Sign() != 0 {
+		t.Errorf("Incorrect simulation tally %s / %s", simulation.VotesFor.String(), simulation.VotesAgainst.String())
+	} else if simulation.AdditionalVotesRequired == 0 || !simulation.CanPass {
+		t.Errorf("Incorrect simulation outcome: additional votes %d, can pass %t", simulation.AdditionalVotesRequired, simulation.CanPass)
+	} else if len(simulation.RemainingVoters) != len(simulation.EligibleVoters) {
+		t.Error("Incorrect simulation remaining voters")
+	}
+
+}
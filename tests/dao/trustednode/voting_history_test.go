@@ -0,0 +1,133 @@
+package trustednode
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/PatriceVignola/rocketpool-go/dao"
+	trustednodedao "github.com/PatriceVignola/rocketpool-go/dao/trustednode"
+	trustednodesettings "github.com/PatriceVignola/rocketpool-go/settings/trustednode"
+	rptypes "github.com/PatriceVignola/rocketpool-go/types"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/accounts"
+	daoutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/dao"
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+	nodeutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/node"
+)
+
+func TestBuildVotingHistory(t *testing.T) {
+
+	// Members & proposals
+	member1 := common.HexToAddress("0x01")
+	member2 := common.HexToAddress("0x02")
+	member3 := common.HexToAddress("0x03")
+	member4 := common.HexToAddress("0x04")
+	members := []trustednodedao.MemberDetails{
+		{Address: member1, ID: "one", JoinedTime: 0},
+		{Address: member2, ID: "two", JoinedTime: 0},
+		{Address: member3, ID: "three", JoinedTime: 150},
+		{Address: member4, ID: "four", JoinedTime: 100},
+	}
+	proposals := []dao.ProposalDetails{
+		{ID: 2, ProposerAddress: member2, CreatedTime: 200, State: rptypes.Active},
+		{ID: 1, ProposerAddress: member1, CreatedTime: 100, State: rptypes.Executed},
+	}
+	votes := []dao.ProposalVote{
+		{ProposalID: 1, Voter: member1, Supported: true},
+		{ProposalID: 1, Voter: member2, Supported: false},
+		{ProposalID: 2, Voter: member3, Supported: true},
+	}
+
+	// Build & check history
+	history := trustednodedao.BuildVotingHistory(members, proposals, votes)
+	if len(history.Proposals) != 2 || history.Proposals[0].ProposalID != 1 {
+		t.Fatalf("Incorrect voting history proposals %v", history.Proposals)
+	}
+	if votes := history.Proposals[0].Votes; len(votes) != 2 || votes[member1] != trustednodedao.VoteFor || votes[member2] != trustednodedao.VoteAgainst {
+		t.Errorf("Incorrect proposal 1 votes %v", votes)
+	}
+	if votes := history.Proposals[1].Votes; len(votes) != 1 || votes[member3] != trustednodedao.VoteFor {
+		t.Errorf("Incorrect proposal 2 votes %v", votes)
+	}
+
+	// Check member stats
+	if stats := history.Members[0]; stats.ProposalsCreated != 1 || stats.EligibleProposals != 1 || stats.ParticipationRate != 1 || stats.AgreementRate != 1 {
+		t.Errorf("Incorrect member 1 stats %+v", stats)
+	}
+	if stats := history.Members[1]; stats.ProposalsCreated != 1 || stats.VotesAgainst != 1 || stats.AgreementRate != 0 || stats.DecidedVotes != 1 {
+		t.Errorf("Incorrect member 2 stats %+v", stats)
+	}
+	if stats := history.Members[2]; stats.EligibleProposals != 1 || stats.DecidedVotes != 0 {
+		t.Errorf("Incorrect member 3 stats %+v", stats)
+	}
+	if stats := history.Members[3]; stats.EligibleProposals != 0 || stats.Abstained != 0 {
+		t.Errorf("Incorrect member 4 stats %+v", stats)
+	}
+
+}
+
+func TestGetVotingHistory(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Set proposal cooldown
+	if _, err := trustednodesettings.BootstrapProposalCooldownTime(rp, 0, ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := trustednodesettings.BootstrapProposalVoteDelayTime(rp, 5, ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Register trusted nodes
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount1); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount2); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount3); err != nil {
+		t.Fatal(err)
+	}
+
+	// Submit, pass & execute proposal
+	proposalId, _, err := trustednodesettings.ProposeQuorum(rp, 0.5, trustedNodeAccount1.GetTransactor())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := daoutils.PassAndExecuteProposal(rp, proposalId, []*accounts.Account{trustedNodeAccount1, trustedNodeAccount2}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Get & check voting history
+	history, err := trustednodedao.GetVotingHistory(rp, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var proposalVotes *trustednodedao.ProposalVotes
+	for pi := range history.Proposals {
+		if history.Proposals[pi].ProposalID == proposalId {
+			proposalVotes = &history.Proposals[pi]
+		}
+	}
+	if proposalVotes == nil {
+		t.Fatal("Proposal not found in voting history")
+	}
+	if proposalVotes.Votes[trustedNodeAccount1.Address] != trustednodedao.VoteFor || proposalVotes.Votes[trustedNodeAccount2.Address] != trustednodedao.VoteFor {
+		t.Errorf("Incorrect proposal votes %v", proposalVotes.Votes)
+	}
+	for _, stats := range history.Members {
+		if stats.Address == trustedNodeAccount1.Address && stats.ProposalsCreated != 1 {
+			t.Errorf("Incorrect member proposals created %d", stats.ProposalsCreated)
+		}
+	}
+
+}
@@ -0,0 +1,156 @@
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PatriceVignola/rocketpool-go/deposit"
+	"github.com/PatriceVignola/rocketpool-go/minipool"
+	"github.com/PatriceVignola/rocketpool-go/network"
+	"github.com/PatriceVignola/rocketpool-go/node"
+	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
+	"github.com/PatriceVignola/rocketpool-go/settings/trustednode"
+	"github.com/PatriceVignola/rocketpool-go/tokens"
+	rptypes "github.com/PatriceVignola/rocketpool-go/types"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+	minipoolutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/minipool"
+)
+
+// Beacon balance source with fixed validator statuses
+type fakeBeaconSource struct {
+	validators map[rptypes.ValidatorPubkey]network.BeaconValidatorStatus
+}
+
+func (b *fakeBeaconSource) GetEpochAtTime(blockTime uint64) (uint64, error) {
+	return blockTime / 384, nil
+}
+func (b *fakeBeaconSource) GetValidatorStatuses(pubkeys []rptypes.ValidatorPubkey, epoch uint64) (map[rptypes.ValidatorPubkey]network.BeaconValidatorStatus, error) {
+	statuses := make(map[rptypes.ValidatorPubkey]network.BeaconValidatorStatus)
+	for _, pubkey := range pubkeys {
+		if status, ok := b.validators[pubkey]; ok {
+			statuses[pubkey] = status
+		}
+	}
+	return statuses, nil
+}
+
+func TestCalculateBalancesReport(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Fix the node fee at 10%
+	if _, err := protocol.BootstrapMinimumNodeFee(rp, 0.1, ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := protocol.BootstrapTargetNodeFee(rp, 0.1, ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := protocol.BootstrapMaximumNodeFee(rp, 0.1, ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Register node & create a half deposit minipool
+	if _, err := node.RegisterNode(rp, "Australia/Brisbane", nodeAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	mp, err := minipoolutils.CreateMinipool(t, rp, ownerAccount, nodeAccount, eth.EthToWei(16), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Make user deposit to fill the minipool
+	opts := userAccount.GetTransactor()
+	opts.Value = eth.EthToWei(16)
+	if _, err := deposit.Deposit(rp, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	// Delay for the scrub period & stake minipool
+	scrubPeriod, err := trustednode.GetScrubPeriod(rp, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := evm.IncreaseTime(int(scrubPeriod + 1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := minipoolutils.StakeMinipool(rp, mp, nodeAccount); err != nil {
+		t.Fatal(err)
+	}
+
+	// Report the minipool's validator as active with a 33 ETH beacon balance
+	pubkey, err := minipool.GetMinipoolPubkey(rp, mp.Address, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	beacon := &fakeBeaconSource{validators: map[rptypes.ValidatorPubkey]network.BeaconValidatorStatus{
+		pubkey: {Exists: true, Balance: 33000000000, ActivationEpoch: 0, ExitEpoch: ^uint64(0)},
+	}}
+
+	// Get current block
+	blockNumber, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Calculate balances report
+	report, err := network.CalculateBalancesReportAtBlock(rp, beacon, blockNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Check minipool balances
+	// 1 ETH of rewards is split evenly, and the node takes a 10% fee on the user half: 16 + 0.5 - 0.05
+	expectedUserBalance := eth.EthToWei(16.45)
+	if len(report.Minipools) != 1 {
+		t.Fatalf("Incorrect minipool count %d", len(report.Minipools))
+	}
+	if report.Minipools[0].BeaconBalance.Cmp(eth.EthToWei(33)) != 0 {
+		t.Errorf("Incorrect minipool beacon balance %s", report.Minipools[0].BeaconBalance.String())
+	}
+	if !report.Minipools[0].IsStaking {
+		t.Error("Incorrect minipool staking status")
+	}
+	if report.MinipoolsTotal.Cmp(expectedUserBalance) != 0 {
+		t.Errorf("Incorrect minipools total balance %s", report.MinipoolsTotal.String())
+	}
+	if report.MinipoolsStaking.Cmp(expectedUserBalance) != 0 {
+		t.Errorf("Incorrect minipools staking balance %s", report.MinipoolsStaking.String())
+	}
+
+	// Check deposit pool balance
+	if depositPoolBalance, err := deposit.GetBalance(rp, nil); err != nil {
+		t.Error(err)
+	} else if report.DepositPool.Cmp(depositPoolBalance) != 0 {
+		t.Errorf("Incorrect deposit pool balance %s", report.DepositPool.String())
+	}
+
+	// Check rETH supply
+	if rethSupply, err := tokens.GetRETHTotalSupply(rp, nil); err != nil {
+		t.Error(err)
+	} else if report.RETHSupply.Cmp(rethSupply) != 0 {
+		t.Errorf("Incorrect rETH supply %s", report.RETHSupply.String())
+	}
+
+	// Check total & staking ETH balances
+	// The user deposit was fully assigned to the minipool, so the deposit pool & rETH contract are empty
+	if report.DepositPool.Sign() != 0 || report.RETHContract.Sign() != 0 {
+		t.Errorf("Incorrect deposit pool & rETH contract balances %s / %s", report.DepositPool.String(), report.RETHContract.String())
+	}
+	if report.TotalETH.Cmp(expectedUserBalance) != 0 {
+		t.Errorf("Incorrect total ETH balance %s", report.TotalETH.String())
+	}
+	if report.StakingETH.Cmp(expectedUserBalance) != 0 {
+		t.Errorf("Incorrect staking ETH balance %s", report.StakingETH.String())
+	}
+
+}
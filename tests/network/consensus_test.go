@@ -0,0 +1,139 @@
+package network
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/PatriceVignola/rocketpool-go/network"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+	nodeutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/node"
+)
+
+func TestGetBalancesConsensus(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Register trusted node
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount); err != nil {
+		t.Fatal(err)
+	}
+
+	// Submit balances
+	var balancesBlock uint64 = 100
+	totalEth := eth.EthToWei(100)
+	stakingEth := eth.EthToWei(80)
+	rethSupply := eth.EthToWei(70)
+	if _, err := network.SubmitBalances(rp, balancesBlock, totalEth, stakingEth, rethSupply, trustedNodeAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Get consensus states
+	intervals, err := network.GetBalancesConsensus(rp, big.NewInt(0), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Check submission interval
+	var interval *network.IntervalConsensus
+	for ii := range intervals {
+		if intervals[ii].Block == balancesBlock {
+			interval = &intervals[ii]
+		}
+	}
+	if interval == nil {
+		t.Fatalf("Balances submission interval %d not found", balancesBlock)
+	}
+	if len(interval.Groups) != 1 {
+		t.Fatalf("Incorrect submission group count %d", len(interval.Groups))
+	}
+	if len(interval.Groups[0].Values) != 3 || interval.Groups[0].Values[0].Cmp(totalEth) != 0 || interval.Groups[0].Values[1].Cmp(stakingEth) != 0 || interval.Groups[0].Values[2].Cmp(rethSupply) != 0 {
+		t.Errorf("Incorrect submission group values %v", interval.Groups[0].Values)
+	}
+	if len(interval.Groups[0].Members) != 1 || interval.Groups[0].Members[0] != trustedNodeAccount.Address {
+		t.Errorf("Incorrect submission group members %v", interval.Groups[0].Members)
+	}
+	if len(interval.DivergentMembers) != 0 {
+		t.Errorf("Incorrect divergent members %v", interval.DivergentMembers)
+	}
+
+}
+
+func TestGetIntervalConsensus(t *testing.T) {
+
+	// Members & submissions
+	members := []common.Address{
+		common.HexToAddress("0x01"),
+		common.HexToAddress("0x02"),
+		common.HexToAddress("0x03"),
+		common.HexToAddress("0x04"),
+	}
+	agreed := []*big.Int{big.NewInt(100), big.NewInt(80)}
+	submissions := []network.OracleSubmission{
+		{Member: members[0], Block: 10, Values: agreed},
+		{Member: members[1], Block: 10, Values: []*big.Int{big.NewInt(100), big.NewInt(80)}},
+		{Member: members[2], Block: 10, Values: []*big.Int{big.NewInt(100), big.NewInt(81)}},
+	}
+
+	// Get consensus state
+	interval := network.GetIntervalConsensus(10, network.PricesSubmissionValueNames, submissions, members, 0.51)
+
+	// Check consensus
+	if interval.SubmissionsNeeded != 3 {
+		t.Errorf("Incorrect submissions needed %d", interval.SubmissionsNeeded)
+	}
+	if interval.ConsensusReached {
+		t.Error("Incorrect consensus reached status")
+	}
+	if len(interval.Groups) != 2 {
+		t.Fatalf("Incorrect submission group count %d", len(interval.Groups))
+	}
+	if len(interval.Groups[0].Members) != 2 || interval.Groups[0].Fraction != 0.5 || interval.Groups[0].SubmissionsToGo != 1 {
+		t.Errorf("Incorrect leading submission group %+v", interval.Groups[0])
+	}
+
+	// Check divergent & missing members
+	if len(interval.DivergentMembers) != 1 || interval.DivergentMembers[0] != members[2] {
+		t.Errorf("Incorrect divergent members %v", interval.DivergentMembers)
+	}
+	if len(interval.MissingMembers) != 1 || interval.MissingMembers[0] != members[3] {
+		t.Errorf("Incorrect missing members %v", interval.MissingMembers)
+	}
+
+	// Check consensus once threshold is reached
+	interval = network.GetIntervalConsensus(10, network.PricesSubmissionValueNames, submissions, members[:3], 0.51)
+	if !interval.ConsensusReached || !interval.Groups[0].ConsensusReached {
+		t.Error("Incorrect consensus reached status")
+	}
+
+	// Check repeated submissions from a member
+	submissions = append(submissions,
+		network.OracleSubmission{Member: members[2], Block: 10, Values: []*big.Int{big.NewInt(100), big.NewInt(80)}},
+		network.OracleSubmission{Member: members[0], Block: 10, Values: []*big.Int{big.NewInt(100), big.NewInt(80)}},
+	)
+	interval = network.GetIntervalConsensus(10, network.PricesSubmissionValueNames, submissions, members, 0.51)
+	if len(interval.Groups) != 2 || len(interval.Groups[0].Members) != 3 || len(interval.Groups[1].Members) != 1 {
+		t.Errorf("Incorrect repeated submission groups %+v", interval.Groups)
+	}
+	if !interval.ConsensusReached {
+		t.Error("Incorrect repeated submission consensus reached status")
+	}
+	if len(interval.DivergentMembers) != 0 {
+		t.Errorf("Incorrect repeated submission divergent members %v", interval.DivergentMembers)
+	}
+	if len(interval.RepeatedMembers) != 1 || interval.RepeatedMembers[0] != members[2] {
+		t.Errorf("Incorrect repeated members %v", interval.RepeatedMembers)
+	}
+
+}
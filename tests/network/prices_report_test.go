@@ -0,0 +1,137 @@
+package network
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/PatriceVignola/rocketpool-go/network"
+	"github.com/PatriceVignola/rocketpool-go/node"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+	nodeutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/node"
+)
+
+// RPL price source with a fixed price
+type fixedPriceSource struct {
+	price *big.Int
+}
+
+func (s *fixedPriceSource) GetRPLPrice(blockNumber uint64) (*big.Int, error) {
+	return s.price, nil
+}
+
+func TestCalculatePricesReport(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Register node & stake RPL
+	if _, err := node.RegisterNode(rp, "Australia/Brisbane", nodeAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.StakeRPL(rp, ownerAccount, nodeAccount, eth.EthToWei(1000)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Get current block
+	blockNumber, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Calculate prices report
+	rplPrice := eth.EthToWei(0.01)
+	report, err := network.CalculatePricesReportAtBlock(rp, &fixedPriceSource{price: rplPrice}, blockNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Check report
+	if report.Block != blockNumber {
+		t.Errorf("Incorrect report block %d", report.Block)
+	}
+	if report.RPLPrice.Cmp(rplPrice) != 0 {
+		t.Errorf("Incorrect report RPL price %s", report.RPLPrice.String())
+	}
+	nodeCount, err := node.GetNodeCount(rp, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if effectiveRplStake, err := node.CalculateTotalEffectiveRPLStake(rp, big.NewInt(0), new(big.Int).SetUint64(nodeCount), rplPrice, nil); err != nil {
+		t.Error(err)
+	} else if report.EffectiveRPLStake.Cmp(effectiveRplStake) != 0 {
+		t.Errorf("Incorrect report effective RPL stake %s", report.EffectiveRPLStake.String())
+	}
+
+}
+
+func TestCalculateTWAP(t *testing.T) {
+
+	// 0.01 ETH per RPL in UQ112x112 over 100 seconds
+	spot := new(big.Int).Lsh(big.NewInt(1), 112)
+	spot.Div(spot, big.NewInt(100))
+	startCumulative := big.NewInt(0)
+	endCumulative := new(big.Int).Mul(spot, big.NewInt(100))
+	if price := network.CalculateTWAP(startCumulative, endCumulative, 100); eth.WeiToEth(price) < 0.0099 || eth.WeiToEth(price) > 0.0101 {
+		t.Errorf("Incorrect TWAP %s", price.String())
+	}
+
+	// Cumulative price overflow
+	startCumulative = new(big.Int).Lsh(big.NewInt(1), 256)
+	startCumulative.Sub(startCumulative, new(big.Int).Mul(spot, big.NewInt(50)))
+	endCumulative = new(big.Int).Mul(spot, big.NewInt(50))
+	if price := network.CalculateTWAP(startCumulative, endCumulative, 100); eth.WeiToEth(price) < 0.0099 || eth.WeiToEth(price) > 0.0101 {
+		t.Errorf("Incorrect TWAP across overflow %s", price.String())
+	}
+
+}
+
+func TestCalculateCumulativePrice(t *testing.T) {
+
+	// 0.01 ETH per RPL in UQ112x112
+	spot := new(big.Int).Lsh(big.NewInt(1), 112)
+	spot.Div(spot, big.NewInt(100))
+	rplReserve := eth.EthToWei(1000)
+	ethReserve := eth.EthToWei(10)
+
+	// Check that the price isn't accumulated in the block of the last pair update
+	cumulativeLast := new(big.Int).Mul(spot, big.NewInt(500))
+	if price := network.CalculateCumulativePrice(cumulativeLast, rplReserve, ethReserve, 1000, 1000); price.Cmp(cumulativeLast) != 0 {
+		t.Errorf("Incorrect cumulative price at last update %s", price.String())
+	}
+
+	// Check that the spot price is accumulated since the last pair update
+	expected := new(big.Int).Mul(spot, big.NewInt(600))
+	if price := network.CalculateCumulativePrice(cumulativeLast, rplReserve, ethReserve, 1000, 1100); price.Cmp(expected) != 0 {
+		t.Errorf("Incorrect counterfactual cumulative price %s; expected %s", price.String(), expected.String())
+	}
+
+	// Check accumulation across a pair timestamp overflow
+	if price := network.CalculateCumulativePrice(cumulativeLast, rplReserve, ethReserve, ^uint32(0)-49, 1<<32+50); price.Cmp(expected) != 0 {
+		t.Errorf("Incorrect counterfactual cumulative price across timestamp overflow %s", price.String())
+	}
+
+	// Check accumulation across a cumulative price overflow
+	cumulativeLast = new(big.Int).Lsh(big.NewInt(1), 256)
+	cumulativeLast.Sub(cumulativeLast, new(big.Int).Mul(spot, big.NewInt(50)))
+	expected = new(big.Int).Mul(spot, big.NewInt(50))
+	if price := network.CalculateCumulativePrice(cumulativeLast, rplReserve, ethReserve, 1000, 1100); price.Cmp(expected) != 0 {
+		t.Errorf("Incorrect counterfactual cumulative price across overflow %s", price.String())
+	}
+
+	// Check that empty reserves aren't accumulated
+	cumulativeLast = new(big.Int).Mul(spot, big.NewInt(500))
+	if price := network.CalculateCumulativePrice(cumulativeLast, big.NewInt(0), ethReserve, 1000, 1100); price.Cmp(cumulativeLast) != 0 {
+		t.Errorf("Incorrect cumulative price with empty reserves %s", price.String())
+	}
+
+}
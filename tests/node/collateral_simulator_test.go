@@ -0,0 +1,113 @@
+package node
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/PatriceVignola/rocketpool-go/node"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+	minipoolutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/minipool"
+	nodeutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/node"
+)
+
+func TestGetCollateralState(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Register node & stake RPL for 2 minipools
+	if _, err := node.RegisterNode(rp, "Australia/Brisbane", nodeAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	minipoolRplRequired, err := minipoolutils.GetMinipoolRPLRequired(rp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rplAmount := new(big.Int).Mul(minipoolRplRequired, big.NewInt(2))
+	if err := nodeutils.StakeRPL(rp, ownerAccount, nodeAccount, rplAmount); err != nil {
+		t.Fatal(err)
+	}
+
+	// Get collateral state & simulate no change
+	state, err := node.GetCollateralState(rp, nodeAccount.Address, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	simulation := state.Simulate(nil, nil)
+
+	// Check simulation against on-chain values
+	if effectiveStake, err := node.GetNodeEffectiveRPLStake(rp, nodeAccount.Address, nil); err != nil {
+		t.Error(err)
+	} else if simulation.EffectiveRPLStake.Cmp(effectiveStake) != 0 {
+		t.Errorf("Incorrect simulated effective RPL stake %s", simulation.EffectiveRPLStake.String())
+	}
+	if minipoolLimit, err := node.GetNodeMinipoolLimit(rp, nodeAccount.Address, nil); err != nil {
+		t.Error(err)
+	} else if simulation.MinipoolLimit != minipoolLimit {
+		t.Errorf("Incorrect simulated minipool limit %d", simulation.MinipoolLimit)
+	}
+
+	// Check withdrawal is blocked by the cooldown
+	if simulation = state.Simulate(new(big.Int).Neg(minipoolRplRequired), nil); !simulation.WithdrawalBlocked || simulation.CooldownRemaining == 0 {
+		t.Error("Simulated withdrawal was not blocked by the cooldown")
+	}
+
+}
+
+func TestSimulateCollateral(t *testing.T) {
+
+	// Node with 2 active minipools, 3200 RPL at 0.01 ETH, 10%-150% stake bounds
+	state := node.CollateralState{
+		Time:                    1000,
+		RPLStake:                eth.EthToWei(3200),
+		RPLStakedTime:           100,
+		ActiveMinipoolCount:     2,
+		RPLPrice:                eth.EthToWei(0.01),
+		HalfDepositUserAmount:   eth.EthToWei(16),
+		MinimumPerMinipoolStake: eth.EthToWei(0.1),
+		MaximumPerMinipoolStake: eth.EthToWei(1.5),
+		WithdrawalCooldown:      500,
+	}
+
+	// Check current state
+	simulation := state.Simulate(nil, nil)
+	if simulation.MinimumRPLStake.Cmp(eth.EthToWei(320)) != 0 || simulation.MaximumRPLStake.Cmp(eth.EthToWei(4800)) != 0 {
+		t.Errorf("Incorrect stake bounds %s - %s", simulation.MinimumRPLStake.String(), simulation.MaximumRPLStake.String())
+	}
+	if simulation.EffectiveRPLStake.Cmp(eth.EthToWei(3200)) != 0 || simulation.MinipoolLimit != 20 {
+		t.Errorf("Incorrect effective stake %s or minipool limit %d", simulation.EffectiveRPLStake.String(), simulation.MinipoolLimit)
+	}
+
+	// Check stake capped at the maximum after staking, with the cooldown restarted
+	simulation = state.Simulate(eth.EthToWei(2000), nil)
+	if simulation.EffectiveRPLStake.Cmp(eth.EthToWei(4800)) != 0 || simulation.CooldownRemaining != 500 {
+		t.Errorf("Incorrect effective stake %s or cooldown %d after staking", simulation.EffectiveRPLStake.String(), simulation.CooldownRemaining)
+	}
+
+	// Check stake below the minimum after an RPL price drop
+	simulation = state.Simulate(nil, eth.EthToWei(0.0001))
+	if simulation.EffectiveRPLStake.Sign() != 0 {
+		t.Errorf("Incorrect effective stake %s after price drop", simulation.EffectiveRPLStake.String())
+	}
+
+	// Check withdrawals
+	if simulation = state.Simulate(eth.EthToWei(-100), nil); !simulation.WithdrawalBlocked || !simulation.BelowWithdrawalMinimum || simulation.CooldownRemaining != 0 {
+		t.Errorf("Incorrect withdrawal simulation %+v", simulation)
+	}
+	if simulation = state.Simulate(eth.EthToWei(-100), eth.EthToWei(1)); simulation.WithdrawalBlocked {
+		t.Errorf("Incorrect withdrawal simulation at a higher RPL price %+v", simulation)
+	}
+	if simulation = state.Simulate(eth.EthToWei(-4000), eth.EthToWei(1)); !simulation.WithdrawalBlocked || !simulation.InsufficientStake {
+		t.Errorf("Incorrect withdrawal simulation exceeding stake %+v", simulation)
+	}
+
+}
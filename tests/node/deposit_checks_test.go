@@ -0,0 +1,90 @@
+package node
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/PatriceVignola/rocketpool-go/node"
+	rptypes "github.com/PatriceVignola/rocketpool-go/types"
+	"github.com/PatriceVignola/rocketpool-go/utils"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+	minipoolutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/minipool"
+	nodeutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/node"
+)
+
+func TestCheckDeposit(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Check deposit from an unregistered node without RPL stake
+	amount := eth.EthToWei(16)
+	salt := big.NewInt(1)
+	preflight, err := node.CheckDeposit(rp, nodeAccount.Address, amount, 0, salt, &common.Address{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preflight.DepositType != rptypes.Half {
+		t.Errorf("Incorrect deposit type %s", preflight.DepositType.String())
+	}
+	failed := map[string]bool{}
+	for _, failure := range preflight.Failures {
+		failed[failure.Check] = true
+	}
+	for _, check := range []string{node.DepositCheckNodeRegistered, node.DepositCheckMinipoolLimit, node.DepositCheckMinipoolAddress} {
+		if !failed[check] {
+			t.Errorf("Deposit check %s did not fail", check)
+		}
+	}
+
+	// Register node & stake RPL
+	if _, err := node.RegisterNode(rp, "Australia/Brisbane", nodeAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	rplRequired, err := minipoolutils.GetMinipoolRPLRequired(rp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.StakeRPL(rp, ownerAccount, nodeAccount, rplRequired); err != nil {
+		t.Fatal(err)
+	}
+
+	// Check deposit without an expected minipool address & check the generated address
+	minipoolAddress, err := utils.GenerateAddress(rp, nodeAccount.Address, rptypes.Half, salt, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preflight, err := node.CheckDeposit(rp, nodeAccount.Address, amount, 0, salt, nil, nil); err != nil {
+		t.Error(err)
+	} else if !preflight.Passed() {
+		t.Errorf("Deposit checks failed: %v", preflight.Failures)
+	} else if preflight.MinipoolAddress != minipoolAddress {
+		t.Errorf("Incorrect generated minipool address %s", preflight.MinipoolAddress.Hex())
+	}
+
+	// Check deposit with the expected minipool address
+	if preflight, err := node.CheckDeposit(rp, nodeAccount.Address, amount, 0, salt, &minipoolAddress, nil); err != nil {
+		t.Error(err)
+	} else if !preflight.Passed() {
+		t.Errorf("Deposit checks failed: %v", preflight.Failures)
+	}
+
+	// Check deposit with an invalid amount & node fee
+	if preflight, err := node.CheckDeposit(rp, nodeAccount.Address, eth.EthToWei(1), 1, salt, &minipoolAddress, nil); err != nil {
+		t.Error(err)
+	} else if len(preflight.Failures) != 2 || preflight.Failures[0].Check != node.DepositCheckDepositType || preflight.Failures[1].Check != node.DepositCheckNodeFee {
+		t.Errorf("Incorrect deposit check failures: %v", preflight.Failures)
+	}
+
+}
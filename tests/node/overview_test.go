@@ -0,0 +1,79 @@
+package node
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/PatriceVignola/rocketpool-go/node"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+	minipoolutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/minipool"
+	nodeutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/node"
+)
+
+func TestGetNodeOverview(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Register node
+	if _, err := node.RegisterNode(rp, "Australia/Brisbane", nodeAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Stake RPL for 2 minipools
+	minipoolRplRequired, err := minipoolutils.GetMinipoolRPLRequired(rp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rplAmount := new(big.Int).Mul(minipoolRplRequired, big.NewInt(2))
+	if err := nodeutils.StakeRPL(rp, ownerAccount, nodeAccount, rplAmount); err != nil {
+		t.Fatal(err)
+	}
+
+	// Get node overview
+	overview, err := node.GetNodeOverview(rp, nodeAccount.Address, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Check overview
+	if !overview.Details.Exists || overview.Details.TimezoneLocation != "Australia/Brisbane" {
+		t.Errorf("Incorrect node details %+v", overview.Details)
+	}
+	if overview.RPLStake.Cmp(rplAmount) != 0 {
+		t.Errorf("Incorrect node RPL stake %s", overview.RPLStake.String())
+	}
+	if overview.MinipoolLimit != 2 || overview.ActiveMinipoolCount != 0 || overview.MinipoolsRemaining != 2 {
+		t.Errorf("Incorrect node minipool limit %d, active count %d, remaining %d", overview.MinipoolLimit, overview.ActiveMinipoolCount, overview.MinipoolsRemaining)
+	}
+	if overview.BorrowedETH.Sign() != 0 || overview.CollateralRatio != 0 {
+		t.Errorf("Incorrect node borrowed ETH %s and collateral ratio %f", overview.BorrowedETH.String(), overview.CollateralRatio)
+	}
+	if len(overview.Minipools) != 0 {
+		t.Errorf("Incorrect node minipool count %d", len(overview.Minipools))
+	}
+
+}
+
+func TestCalculateCollateralRatio(t *testing.T) {
+
+	// 1600 RPL at 0.01 ETH against 16 ETH borrowed
+	if ratio := node.CalculateCollateralRatio(eth.EthToWei(1600), eth.EthToWei(0.01), eth.EthToWei(16)); ratio < 0.9999 || ratio > 1.0001 {
+		t.Errorf("Incorrect collateral ratio %f", ratio)
+	}
+
+	// No borrowed ETH
+	if ratio := node.CalculateCollateralRatio(eth.EthToWei(1600), eth.EthToWei(0.01), big.NewInt(0)); ratio != 0 {
+		t.Errorf("Incorrect collateral ratio with no borrowed ETH %f", ratio)
+	}
+
+}
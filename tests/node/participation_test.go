@@ -0,0 +1,124 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/PatriceVignola/rocketpool-go/node"
+	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
+)
+
+func TestCalculateParticipation(t *testing.T) {
+
+	// Get current block & balances update frequency
+	blockNumber, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	updateFrequency, err := protocol.GetSubmitBalancesFrequency(rp, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Calculate participation
+	report, err := node.CalculateParticipation(rp, node.BalancesReportType, 0, blockNumber, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Check report window
+	if report.ReportType != node.BalancesReportType {
+		t.Errorf("Incorrect report type %s", report.ReportType)
+	}
+	if report.UpdateFrequency != updateFrequency {
+		t.Errorf("Incorrect update frequency %d", report.UpdateFrequency)
+	}
+	if expectedIntervals := blockNumber/updateFrequency + 1; uint64(len(report.Intervals)) != expectedIntervals {
+		t.Errorf("Incorrect interval count %d", len(report.Intervals))
+	}
+
+	// Check legacy participation at a block before the first 50 intervals
+	opts := &bind.CallOpts{BlockNumber: big.NewInt(int64(blockNumber))}
+	if participation, err := node.CalculateTrustedNodeBalancesParticipation(rp, nil, opts); err != nil {
+		t.Error(err)
+	} else if participation.UpdateFrequency != updateFrequency || participation.StartBlock%updateFrequency != 0 {
+		t.Errorf("Incorrect legacy participation window %d / %d", participation.StartBlock, participation.UpdateFrequency)
+	} else if participation.StartBlock < blockNumber && participation.UpdateCount != (blockNumber-participation.StartBlock)/updateFrequency+1 {
+		t.Errorf("Incorrect legacy update count %d", participation.UpdateCount)
+	}
+
+	// Check invalid report type
+	if _, err := node.CalculateParticipation(rp, node.ReportType("invalid"), 0, blockNumber, nil, nil); err == nil {
+		t.Error("CalculateParticipation returned no error for an invalid report type")
+	}
+
+}
+
+func TestCalculateParticipationFromSubmissions(t *testing.T) {
+
+	// Members & submissions
+	member1 := common.HexToAddress("0x01")
+	member2 := common.HexToAddress("0x02")
+	member3 := common.HexToAddress("0x03")
+	members := []common.Address{member1, member2, member3}
+	intervals := []uint64{100, 200, 300, 400}
+	submissions := map[common.Address][]uint64{
+		member1: {100, 200, 300, 400},
+		member2: {100, 200, 250, 300, 400, 500},
+		member3: {},
+	}
+
+	// Calculate participation
+	report := node.CalculateParticipationFromSubmissions(members, submissions, intervals)
+
+	// Check expected submissions; 2 of 3 members required per interval
+	if expected := float64(4) * 2 / 3; report.ExpectedSubmissions != expected {
+		t.Errorf("Incorrect expected submissions %f", report.ExpectedSubmissions)
+	}
+
+	// Check member participation
+	if report.Members[0].Submissions != 4 || report.Members[0].ParticipationRate != 1 {
+		t.Errorf("Incorrect member 1 participation %+v", report.Members[0])
+	}
+	if report.Members[1].Submissions != 4 || report.Members[1].TotalSubmissions != 6 {
+		t.Errorf("Incorrect member 2 submissions %d / %d", report.Members[1].Submissions, report.Members[1].TotalSubmissions)
+	}
+	if report.Members[2].Submissions != 0 || report.Members[2].Participation[0] {
+		t.Errorf("Incorrect member 3 participation %+v", report.Members[2])
+	}
+
+	// Check chi-square
+	chi := float64(0)
+	for _, member := range report.Members {
+		chi += member.ChiSquare
+	}
+	if report.ChiSquare != chi || chi == 0 {
+		t.Errorf("Incorrect chi-square %f", report.ChiSquare)
+	}
+	if report.Probability <= 0 || report.Probability >= 1 {
+		t.Errorf("Incorrect probability %f", report.Probability)
+	}
+
+	// Check CSV export
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 4 || len(rows[0]) != 5+len(intervals) {
+		t.Fatalf("Incorrect CSV dimensions")
+	}
+	if rows[3][0] != member3.Hex() || rows[3][5] != "0" || rows[1][5] != "1" {
+		t.Errorf("Incorrect CSV member rows %v", rows[1:])
+	}
+
+}
@@ -0,0 +1,78 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/PatriceVignola/rocketpool-go/node"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+)
+
+func TestGetTimezoneStatistics(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Register node
+	if _, err := node.RegisterNode(rp, "Australia/Brisbane", nodeAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Get & check timezone statistics
+	stats, err := node.GetTimezoneStatistics(rp, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.NodeCount != 1 {
+		t.Errorf("Incorrect node count %d", stats.NodeCount)
+	}
+	if stats.TimezoneCounts["Australia/Brisbane"] != 1 {
+		t.Errorf("Incorrect timezone counts %v", stats.TimezoneCounts)
+	}
+	if stats.RegionCounts["Australia"] != 1 {
+		t.Errorf("Incorrect region counts %v", stats.RegionCounts)
+	}
+	if timezones := stats.SortedTimezones(); len(timezones) != 1 || timezones[0].Timezone != "Australia/Brisbane" {
+		t.Errorf("Incorrect sorted timezones %v", timezones)
+	}
+
+}
+
+func TestValidateTimezoneLocation(t *testing.T) {
+
+	// Check valid timezones
+	for _, timezone := range []string{"Australia/Brisbane", "America/Argentina/Buenos_Aires", "UTC", "Etc/UTC"} {
+		if err := node.ValidateTimezoneLocation(timezone); err != nil {
+			t.Errorf("Valid timezone %s was rejected: %s", timezone, err)
+		}
+	}
+
+	// Check invalid timezones
+	for _, timezone := range []string{"", "Local", "Mars/Olympus_Mons", "../etc/passwd"} {
+		if err := node.ValidateTimezoneLocation(timezone); err == nil {
+			t.Errorf("Invalid timezone %s was accepted", timezone)
+		}
+	}
+
+	// Check timezone regions
+	regions := map[string]string{
+		"Australia/Brisbane":             "Australia",
+		"America/Argentina/Buenos_Aires": "America",
+		"US/Pacific":                     "America",
+		"Etc/GMT+2":                      node.OtherTimezoneRegion,
+		"UTC":                            node.OtherTimezoneRegion,
+	}
+	for timezone, expected := range regions {
+		if region := node.GetTimezoneRegion(timezone); region != expected {
+			t.Errorf("Incorrect region %s for timezone %s", region, timezone)
+		}
+	}
+
+}
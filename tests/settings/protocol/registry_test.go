@@ -0,0 +1,79 @@
+package protocol
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PatriceVignola/rocketpool-go/settings"
+	"github.com/PatriceVignola/rocketpool-go/settings/trustednode"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+)
+
+// Get the names of all settings in a snapshot type
+func getSettingNames(prefix string, t reflect.Type, claimers []string) []string {
+	names := []string{}
+	for fi := 0; fi < t.NumField(); fi++ {
+		field := t.Field(fi)
+		name := prefix + "." + strings.Split(field.Tag.Get("json"), ",")[0]
+		switch field.Type.Kind() {
+		case reflect.Struct:
+			names = append(names, getSettingNames(name, field.Type, claimers)...)
+		case reflect.Map:
+			for _, claimer := range claimers {
+				names = append(names, name+"."+claimer)
+			}
+		default:
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func TestSettingsMetadata(t *testing.T) {
+
+	// Check every snapshot setting has metadata
+	names := getSettingNames("protocol", reflect.TypeOf(settings.ProtocolSettings{}), settings.RewardsClaimerContractNames)
+	names = append(names, getSettingNames("trustedNode", reflect.TypeOf(settings.TrustedNodeSettings{}), nil)...)
+	if len(names) != len(settings.SettingsMetadata) {
+		t.Errorf("Incorrect settings metadata count %d; expected %d", len(settings.SettingsMetadata), len(names))
+	}
+	for _, name := range names {
+		if _, ok := settings.GetSettingMetadata(name); !ok {
+			t.Errorf("Missing metadata for setting %s", name)
+		}
+	}
+
+	// Get setting by path
+	if metadata, ok := settings.GetSettingMetadataByPath(trustednode.MembersSettingsContractName, trustednode.QuorumSettingPath); !ok {
+		t.Error("Could not get quorum setting metadata by path")
+	} else if metadata.Name != "trustedNode.members.quorum" || metadata.Unit != settings.UnitFraction || metadata.Control != settings.ControlTrustedNode {
+		t.Errorf("Incorrect quorum setting metadata %v", metadata)
+	} else if err := metadata.ValidateValue(eth.EthToWei(0.51)); err != nil {
+		t.Error(err)
+	} else if err := metadata.ValidateValue(eth.EthToWei(1.5)); err == nil {
+		t.Error("Out of bounds quorum value was validated")
+	} else if err := metadata.ValidateValue(big.NewInt(-1)); err == nil {
+		t.Error("Negative quorum value was validated")
+	}
+
+	// Format setting values
+	if value := settings.FormatSettingValue(trustednode.MembersSettingsContractName, trustednode.QuorumSettingPath, eth.EthToWei(0.6)); value != "0.6" {
+		t.Errorf("Incorrect formatted quorum value %s", value)
+	}
+	if value := settings.FormatSettingValue(trustednode.MembersSettingsContractName, "unknown.setting", big.NewInt(5)); value != "5" {
+		t.Errorf("Incorrect formatted unknown setting value %s", value)
+	}
+
+	// Get settings by control type
+	for _, metadata := range settings.GetSettingsMetadataByControl(settings.ControlTrustedNode) {
+		if metadata.Path == "" {
+			t.Errorf("Trusted node setting %s has no path", metadata.Name)
+		}
+	}
+	if constants := settings.GetSettingsMetadataByControl(settings.ControlConstant); len(constants) == 0 {
+		t.Error("Incorrect constant settings count")
+	}
+
+}
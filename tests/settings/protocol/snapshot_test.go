@@ -0,0 +1,79 @@
+package protocol
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/PatriceVignola/rocketpool-go/settings"
+	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+)
+
+func TestSettingsSnapshotDiff(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Get initial settings
+	blockNumber, err := client.BlockNumber(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := settings.GetAllSettings(rp, &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(blockNumber)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before.Protocol.Auction.LotMinimumEthValue == nil || before.TrustedNode.Members.RPLBond == nil {
+		t.Error("Incorrect settings snapshot")
+	}
+
+	// Check an unchanged snapshot has no differences
+	if changes := settings.Diff(before, before); len(changes) != 0 {
+		t.Errorf("Incorrect unchanged settings diff %v", changes)
+	}
+
+	// Change settings
+	lotDuration := before.Protocol.Auction.LotDuration + 10
+	if _, err := protocol.BootstrapLotDuration(rp, lotDuration, ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	createLotEnabled := !before.Protocol.Auction.CreateLotEnabled
+	if _, err := protocol.BootstrapCreateLotEnabled(rp, createLotEnabled, ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Get & check settings diff
+	after, err := settings.GetAllSettings(rp, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := settings.Diff(before, after)
+	if len(changes) != 2 {
+		t.Fatalf("Incorrect settings change count %d", len(changes))
+	}
+	if changes[0].Name != "protocol.auction.createLotEnabled" || changes[0].OldValue != !createLotEnabled || changes[0].NewValue != createLotEnabled {
+		t.Errorf("Incorrect settings change %s", changes[0].String())
+	}
+	if changes[1].Name != "protocol.auction.lotDuration" || changes[1].OldValue != before.Protocol.Auction.LotDuration || changes[1].NewValue != lotDuration {
+		t.Errorf("Incorrect settings change %s", changes[1].String())
+	}
+
+	// Check big int settings are compared by value
+	copied := after
+	copied.TrustedNode.Members.RPLBond = new(big.Int).Set(after.TrustedNode.Members.RPLBond)
+	if changes := settings.Diff(after, copied); len(changes) != 0 {
+		t.Errorf("Incorrect big int settings diff %v", changes)
+	}
+
+}
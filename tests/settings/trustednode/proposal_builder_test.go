@@ -0,0 +1,98 @@
+package trustednode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PatriceVignola/rocketpool-go/settings"
+	"github.com/PatriceVignola/rocketpool-go/settings/trustednode"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/accounts"
+	daoutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/dao"
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+	nodeutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/node"
+)
+
+func TestSettingProposalBuilder(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Set proposal cooldown
+	if _, err := trustednode.BootstrapProposalCooldownTime(rp, 0, ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := trustednode.BootstrapProposalVoteDelayTime(rp, 5, ownerAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Register trusted nodes
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount1); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount2); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount3); err != nil {
+		t.Fatal(err)
+	}
+
+	// Check invalid proposals are rejected
+	if _, err := settings.NewTrustedNodeSettingProposal(rp, "trustedNode.members.unknown", 1.0); err == nil {
+		t.Error("Built a proposal for an unknown setting")
+	}
+	if _, err := settings.NewTrustedNodeSettingProposal(rp, "protocol.deposit.depositEnabled", false); err == nil {
+		t.Error("Built a trusted node proposal for a protocol DAO setting")
+	}
+	if _, err := settings.NewTrustedNodeSettingProposal(rp, "trustedNode.members.quorum", uint64(1)); err == nil {
+		t.Error("Built a proposal with an incorrect value type")
+	}
+	if _, err := settings.NewTrustedNodeSettingProposal(rp, "trustedNode.members.quorum", 1.5); err == nil {
+		t.Error("Built a proposal with an out of bounds value")
+	}
+
+	// Build & check proposal
+	quorum := 0.1
+	proposal, err := settings.NewTrustedNodeSettingProposal(rp, "trustedNode.members.quorum", quorum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proposal.Message != "set members.quorum" {
+		t.Errorf("Incorrect proposal message %s", proposal.Message)
+	}
+	if !strings.HasPrefix(proposal.PayloadString, "proposalSettingUint(rocketDAONodeTrustedSettingsMembers,members.quorum,100000000000000000)") {
+		t.Errorf("Incorrect proposal payload string %s", proposal.PayloadString)
+	}
+
+	// Submit, pass & check proposal
+	if proposalId, _, err := proposal.Submit(trustedNodeAccount1.GetTransactor()); err != nil {
+		t.Error(err)
+	} else if err := daoutils.PassAndExecuteProposal(rp, proposalId, []*accounts.Account{trustedNodeAccount1, trustedNodeAccount2}); err != nil {
+		t.Error(err)
+	} else if value, err := trustednode.GetQuorum(rp, nil); err != nil {
+		t.Error(err)
+	} else if value != quorum {
+		t.Error("Incorrect quorum value")
+	}
+
+	// Build & submit bool proposal
+	if proposal, err := settings.NewTrustedNodeSettingProposal(rp, "trustedNode.minipool.scrubPenaltyEnabled", true); err != nil {
+		t.Error(err)
+	} else if proposalId, _, err := proposal.Submit(trustedNodeAccount1.GetTransactor()); err != nil {
+		t.Error(err)
+	} else if err := daoutils.PassAndExecuteProposal(rp, proposalId, []*accounts.Account{trustedNodeAccount1, trustedNodeAccount2}); err != nil {
+		t.Error(err)
+	} else if value, err := trustednode.GetScrubPenaltyEnabled(rp, nil); err != nil {
+		t.Error(err)
+	} else if !value {
+		t.Error("Incorrect scrub penalty enabled value")
+	}
+
+}
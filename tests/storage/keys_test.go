@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/PatriceVignola/rocketpool-go/node"
+	"github.com/PatriceVignola/rocketpool-go/settings/protocol"
+	"github.com/PatriceVignola/rocketpool-go/settings/trustednode"
+	"github.com/PatriceVignola/rocketpool-go/storage"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+)
+
+func TestKey(t *testing.T) {
+
+	// Check packed key parts
+	nodeAddress := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	if key, err := storage.Key(storage.DAOProposalNamespace, "proposer", uint64(5)); err != nil {
+		t.Error(err)
+	} else if key != storage.DAOProposalKey("proposer", 5) {
+		t.Errorf("Incorrect proposal key %s", key.Hex())
+	}
+	if key, err := storage.Key(storage.NodeExistsNamespace, nodeAddress); err != nil {
+		t.Error(err)
+	} else if key != storage.NodeExistsKey(nodeAddress) {
+		t.Errorf("Incorrect node exists key %s", key.Hex())
+	}
+	if key, err := storage.Key("flag", true, big.NewInt(1)); err != nil {
+		t.Error(err)
+	} else if key != crypto.Keccak256Hash([]byte("flag"), []byte{1}, common.BigToHash(big.NewInt(1)).Bytes()) {
+		t.Errorf("Incorrect mixed part key %s", key.Hex())
+	}
+
+	// Check unsupported key parts
+	if _, err := storage.Key(storage.NodeExistsNamespace, 1.5); err == nil {
+		t.Error("Derived a key from an unsupported part type")
+	}
+
+}
+
+func TestStorageKeys(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Check key derivation
+	if key := storage.ContractAddressKey("rocketNodeManager"); key != crypto.Keccak256Hash([]byte("contract.address"), []byte("rocketNodeManager")) {
+		t.Errorf("Incorrect contract address key %s", key.Hex())
+	}
+
+	// Get & check contract address
+	if rocketNodeManagerAddress, err := rp.GetAddress("rocketNodeManager"); err != nil {
+		t.Error(err)
+	} else if address, err := storage.GetAddress(rp, storage.ContractAddressKey("rocketNodeManager"), nil); err != nil {
+		t.Error(err)
+	} else if address != *rocketNodeManagerAddress {
+		t.Errorf("Incorrect contract address %s", address.Hex())
+	} else if exists, err := storage.GetBool(rp, storage.ContractExistsKey(address), nil); err != nil {
+		t.Error(err)
+	} else if !exists {
+		t.Error("Incorrect contract exists status")
+	}
+
+	// Get & check typed contract values
+	if rocketNodeManagerAddress, err := rp.GetAddress("rocketNodeManager"); err != nil {
+		t.Error(err)
+	} else if address, err := storage.GetContractAddress(rp, "rocketNodeManager", nil); err != nil {
+		t.Error(err)
+	} else if address != *rocketNodeManagerAddress {
+		t.Errorf("Incorrect typed contract address %s", address.Hex())
+	} else if exists, err := storage.GetContractExists(rp, address, nil); err != nil {
+		t.Error(err)
+	} else if !exists {
+		t.Error("Incorrect typed contract exists status")
+	} else if name, err := storage.GetContractName(rp, address, nil); err != nil {
+		t.Error(err)
+	} else if name != "rocketNodeManager" {
+		t.Errorf("Incorrect typed contract name %s", name)
+	}
+	if rocketNodeManagerAbi, err := rp.GetABI("rocketNodeManager"); err != nil {
+		t.Error(err)
+	} else if contractAbi, err := storage.GetContractABI(rp, "rocketNodeManager", nil); err != nil {
+		t.Error(err)
+	} else if contractAbi == nil || len(contractAbi.Methods) != len(rocketNodeManagerAbi.Methods) {
+		t.Error("Incorrect typed contract ABI")
+	}
+	if contractAbi, err := storage.GetContractABI(rp, "rocketNonexistentContract", nil); err != nil {
+		t.Error(err)
+	} else if contractAbi != nil {
+		t.Error("Got an ABI for an unregistered contract")
+	}
+
+	// Register node
+	if _, err := node.RegisterNode(rp, "Australia/Brisbane", nodeAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Get & check node values
+	if exists, err := storage.GetBool(rp, storage.NodeExistsKey(nodeAccount.Address), nil); err != nil {
+		t.Error(err)
+	} else if !exists {
+		t.Error("Incorrect node exists status")
+	}
+	if timezoneLocation, err := storage.GetString(rp, storage.NodeTimezoneLocationKey(nodeAccount.Address), nil); err != nil {
+		t.Error(err)
+	} else if timezoneLocation != "Australia/Brisbane" {
+		t.Errorf("Incorrect node timezone location %s", timezoneLocation)
+	}
+
+	// Get & check typed node values
+	if nodeExists, err := node.GetNodeExists(rp, nodeAccount.Address, nil); err != nil {
+		t.Error(err)
+	} else if exists, err := storage.GetNodeExists(rp, nodeAccount.Address, nil); err != nil {
+		t.Error(err)
+	} else if exists != nodeExists || !exists {
+		t.Errorf("Incorrect typed node exists status %t", exists)
+	}
+	if exists, err := storage.GetNodeExists(rp, ownerAccount.Address, nil); err != nil {
+		t.Error(err)
+	} else if exists {
+		t.Error("Incorrect typed unregistered node exists status")
+	}
+	if nodeTimezoneLocation, err := node.GetNodeTimezoneLocation(rp, nodeAccount.Address, nil); err != nil {
+		t.Error(err)
+	} else if timezoneLocation, err := storage.GetNodeTimezoneLocation(rp, nodeAccount.Address, nil); err != nil {
+		t.Error(err)
+	} else if timezoneLocation != nodeTimezoneLocation {
+		t.Errorf("Incorrect typed node timezone location %s", timezoneLocation)
+	}
+
+	// Get & check protocol setting
+	if launchTimeout, err := protocol.GetMinipoolLaunchTimeout(rp, nil); err != nil {
+		t.Error(err)
+	} else if value, err := storage.GetUint(rp, storage.ProtocolSettingKey("minipool", "minipool.launch.timeout"), nil); err != nil {
+		t.Error(err)
+	} else if time.Duration(value.Int64())*time.Second != launchTimeout {
+		t.Errorf("Incorrect minipool launch timeout setting %s", value.String())
+	} else if typedValue, err := storage.GetProtocolSettingUint(rp, "minipool", "minipool.launch.timeout", nil); err != nil {
+		t.Error(err)
+	} else if typedValue.Cmp(value) != 0 {
+		t.Errorf("Incorrect typed minipool launch timeout setting %s", typedValue.String())
+	}
+	if depositEnabled, err := protocol.GetDepositEnabled(rp, nil); err != nil {
+		t.Error(err)
+	} else if value, err := storage.GetProtocolSettingBool(rp, "deposit", "deposit.enabled", nil); err != nil {
+		t.Error(err)
+	} else if value != depositEnabled {
+		t.Errorf("Incorrect typed deposit enabled setting %t", value)
+	}
+	if quorum, err := trustednode.GetQuorum(rp, nil); err != nil {
+		t.Error(err)
+	} else if value, err := storage.GetTrustedNodeSettingUint(rp, "members", trustednode.QuorumSettingPath, nil); err != nil {
+		t.Error(err)
+	} else if eth.WeiToEth(value) != quorum {
+		t.Errorf("Incorrect typed quorum setting %s", value.String())
+	}
+
+}
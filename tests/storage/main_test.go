@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+
+	"github.com/PatriceVignola/rocketpool-go/tests"
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/accounts"
+)
+
+var (
+	client *ethclient.Client
+	rp     *rocketpool.RocketPool
+
+	ownerAccount      *accounts.Account
+	nodeAccount       *accounts.Account
+	withdrawalAccount *accounts.Account
+)
+
+func TestMain(m *testing.M) {
+	var err error
+
+	// Initialize eth client
+	client, err = ethclient.Dial(tests.Eth1ProviderAddress)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Initialize contract manager
+	rp, err = rocketpool.NewRocketPool(client, common.HexToAddress(tests.RocketStorageAddress))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Initialize accounts
+	ownerAccount, err = accounts.GetAccount(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	nodeAccount, err = accounts.GetAccount(1)
+	if err != nil {
+		log.Fatal(err)
+	}
+	withdrawalAccount, err = accounts.GetAccount(2)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Run tests
+	os.Exit(m.Run())
+
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/PatriceVignola/rocketpool-go/storage"
+)
+
+// A hand-built RocketStorage proof fixture, with the block state root
+// The proof is for a single-account state holding placeholder RocketStorage values, not a live Rocket Pool deployment
+type storageProofFixture struct {
+	StateRoot common.Hash          `json:"stateRoot"`
+	Proof     storage.AccountProof `json:"proof"`
+}
+
+// A proof client returning a fixed proof
+type fixtureProofClient struct {
+	proof storage.AccountProof
+}
+
+func (c *fixtureProofClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	*(result.(*storage.AccountProof)) = c.proof
+	return nil
+}
+
+// Load the RocketStorage proof fixture
+func loadStorageProofFixture(t *testing.T) storageProofFixture {
+	data, err := ioutil.ReadFile("testdata/rocket-storage-proof.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixture storageProofFixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		t.Fatal(err)
+	}
+	return fixture
+}
+
+func TestVerifiedStorageReads(t *testing.T) {
+
+	// Create reader
+	fixture := loadStorageProofFixture(t)
+	reader := &storage.VerifiedStorageReader{
+		Client:         &fixtureProofClient{proof: fixture.Proof},
+		StorageAddress: fixture.Proof.Address,
+		StateRoot:      fixture.StateRoot,
+		Layout:         storage.DefaultStorageLayout,
+	}
+	nodeAddress := common.HexToAddress("0x18a58E43c37DdC9ccCf3AC642c6f430ad663E400")
+
+	// Check values
+	if deployBlock, err := reader.GetUint(storage.DeployBlockKey()); err != nil {
+		t.Error(err)
+	} else if deployBlock.Cmp(big.NewInt(12345)) != 0 {
+		t.Errorf("Incorrect deploy block %s", deployBlock.String())
+	}
+	if address, err := reader.GetContractAddress("rocketNodeManager"); err != nil {
+		t.Error(err)
+	} else if address != common.HexToAddress("0x2222222222222222222222222222222222222222") {
+		t.Errorf("Incorrect contract address %s", address.Hex())
+	}
+	if exists, err := reader.GetBool(storage.NodeExistsKey(nodeAddress)); err != nil {
+		t.Error(err)
+	} else if !exists {
+		t.Error("Incorrect node exists status")
+	}
+	if timezone, err := reader.GetString(storage.NodeTimezoneLocationKey(nodeAddress)); err != nil {
+		t.Error(err)
+	} else if timezone != "Australia/Brisbane" {
+		t.Errorf("Incorrect node timezone location %s", timezone)
+	}
+	if abi, err := reader.GetString(storage.ContractABIKey("rocketNodeManager")); err != nil {
+		t.Error(err)
+	} else if abi != "A long RocketStorage string value spanning more than one storage slot" {
+		t.Errorf("Incorrect contract ABI %s", abi)
+	}
+	if withdrawalAddress, err := reader.GetNodeWithdrawalAddress(nodeAddress); err != nil {
+		t.Error(err)
+	} else if withdrawalAddress != common.HexToAddress("0x1111111111111111111111111111111111111111") {
+		t.Errorf("Incorrect node withdrawal address %s", withdrawalAddress.Hex())
+	}
+	if pendingWithdrawalAddress, err := reader.GetNodePendingWithdrawalAddress(nodeAddress); err != nil {
+		t.Error(err)
+	} else if pendingWithdrawalAddress != (common.Address{}) {
+		t.Errorf("Incorrect node pending withdrawal address %s", pendingWithdrawalAddress.Hex())
+	}
+
+	// Check a wrong state root is rejected
+	wrongRootReader := *reader
+	wrongRootReader.StateRoot = common.HexToHash("0x01")
+	if _, err := wrongRootReader.GetUint(storage.DeployBlockKey()); err == nil {
+		t.Error("Proof was verified against an incorrect state root")
+	}
+
+	// Check a tampered value is rejected
+	tampered := loadStorageProofFixture(t)
+	for pi := range tampered.Proof.StorageProof {
+		tampered.Proof.StorageProof[pi].Value = (*hexutil.Big)(big.NewInt(54321))
+	}
+	tamperedReader := *reader
+	tamperedReader.Client = &fixtureProofClient{proof: tampered.Proof}
+	if _, err := tamperedReader.GetUint(storage.DeployBlockKey()); err == nil {
+		t.Error("Tampered storage value was verified")
+	}
+
+}
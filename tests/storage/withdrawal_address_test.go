@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/PatriceVignola/rocketpool-go/node"
+	"github.com/PatriceVignola/rocketpool-go/storage"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+)
+
+func TestWithdrawalAddressWorkflow(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Register node
+	if _, err := node.RegisterNode(rp, "Australia/Brisbane", nodeAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Create workflow
+	workflow, err := storage.NewWithdrawalAddressWorkflow(rp, nodeAccount.Address, withdrawalAccount.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state, err := workflow.GetState(nil); err != nil {
+		t.Error(err)
+	} else if state != storage.WithdrawalAddressChangeNone {
+		t.Errorf("Incorrect initial withdrawal address change state %s", state)
+	}
+
+	// Check set from an account other than the current withdrawal address
+	if err := workflow.CheckSet(withdrawalAccount.Address, nil); err == nil {
+		t.Error("Withdrawal address could be set by an account other than the current withdrawal address")
+	}
+
+	// Check contracts that cannot receive ETH are rejected
+	rocketNodeManagerAddress, err := rp.GetAddress("rocketNodeManager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if canReceive, err := storage.GetAddressCanReceiveETH(rp, *rocketNodeManagerAddress, nodeAccount.Address, nil); err != nil {
+		t.Error(err)
+	} else if canReceive {
+		t.Error("Contract without a receive function was reported as able to receive ETH")
+	}
+
+	// Set withdrawal address
+	if _, err := workflow.Set(false, nodeAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	if state, err := workflow.GetState(nil); err != nil {
+		t.Error(err)
+	} else if state != storage.WithdrawalAddressChangePending {
+		t.Errorf("Incorrect pending withdrawal address change state %s", state)
+	}
+
+	// Check confirm from an account other than the pending withdrawal address
+	if _, err := workflow.Confirm(nodeAccount.GetTransactor()); err == nil {
+		t.Error("Withdrawal address could be confirmed by an account other than the pending withdrawal address")
+	}
+
+	// Confirm withdrawal address
+	if _, err := workflow.Confirm(withdrawalAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+	if state, err := workflow.GetState(nil); err != nil {
+		t.Error(err)
+	} else if state != storage.WithdrawalAddressChangeConfirmed {
+		t.Errorf("Incorrect confirmed withdrawal address change state %s", state)
+	}
+
+	// Get & check withdrawal address history
+	if history, err := storage.GetWithdrawalAddressHistory(rp, nodeAccount.Address, nil, nil); err != nil {
+		t.Error(err)
+	} else if len(history) == 0 || history[len(history)-1].WithdrawalAddress != withdrawalAccount.Address {
+		t.Errorf("Incorrect withdrawal address history %v", history)
+	}
+
+}
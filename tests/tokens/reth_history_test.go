@@ -0,0 +1,109 @@
+package tokens
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/PatriceVignola/rocketpool-go/network"
+	"github.com/PatriceVignola/rocketpool-go/tokens"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+
+	"github.com/PatriceVignola/rocketpool-go/tests/testutils/evm"
+	nodeutils "github.com/PatriceVignola/rocketpool-go/tests/testutils/node"
+)
+
+func TestGetRETHExchangeRateHistory(t *testing.T) {
+
+	// State snapshotting
+	if err := evm.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := evm.RevertSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Get start block
+	fromBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Register trusted node
+	if err := nodeutils.RegisterTrustedNode(rp, ownerAccount, trustedNodeAccount); err != nil {
+		t.Fatal(err)
+	}
+
+	// Submit network balances
+	if _, err := network.SubmitBalances(rp, 1, eth.EthToWei(100), eth.EthToWei(100), eth.EthToWei(50), trustedNodeAccount.GetTransactor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Get end block
+	toBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Get & check exchange rate history
+	samples, err := tokens.GetRETHExchangeRateHistory(rp, fromBlock, toBlock, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 1 {
+		t.Fatalf("Incorrect exchange rate sample count %d", len(samples))
+	}
+	if samples[0].Rate.Cmp(eth.EthToWei(2)) != 0 {
+		t.Errorf("Incorrect exchange rate %s", samples[0].Rate.String())
+	}
+
+	// Get & check exchange rate series
+	series, err := tokens.GetRETHExchangeRateSeries(rp, toBlock, toBlock, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(series) != 1 || series[0].Rate.Cmp(samples[0].Rate) != 0 {
+		t.Errorf("Incorrect exchange rate series %v", series)
+	}
+
+}
+
+func TestCalculateRETHAPR(t *testing.T) {
+
+	// Check exchange rate calculation
+	if rate := tokens.CalculateRETHRate(eth.EthToWei(110), eth.EthToWei(100)); rate.Cmp(eth.EthToWei(1.1)) != 0 {
+		t.Errorf("Incorrect exchange rate %s", rate.String())
+	}
+	if rate := tokens.CalculateRETHRate(big.NewInt(0), big.NewInt(0)); rate.Cmp(eth.EthToWei(1)) != 0 {
+		t.Errorf("Incorrect exchange rate with no supply %s", rate.String())
+	}
+
+	// Daily samples growing at 5% APR
+	day := uint64(24 * 60 * 60)
+	samples := []tokens.RETHRateSample{}
+	for i := uint64(0); i <= 30; i++ {
+		rate := eth.EthToWei(1 + 0.05*float64(i)/365)
+		samples = append(samples, tokens.RETHRateSample{Block: i, Time: i * day, Rate: rate})
+	}
+
+	// Get & check 7 day APRs
+	aprs := tokens.CalculateRETHAPR(samples, 7*24*time.Hour)
+	if len(aprs) != 24 {
+		t.Fatalf("Incorrect APR sample count %d", len(aprs))
+	}
+	if aprs[0].StartBlock != 0 || aprs[0].Block != 7 {
+		t.Errorf("Incorrect APR window %d-%d", aprs[0].StartBlock, aprs[0].Block)
+	}
+	for _, apr := range aprs {
+		start := 1 + 0.05*float64(apr.StartBlock)/365
+		expected := 0.05 / start
+		if math.Abs(apr.APR-expected) > 1e-9 {
+			t.Errorf("Incorrect APR %f at block %d", apr.APR, apr.Block)
+		}
+	}
+
+}
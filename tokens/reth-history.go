@@ -0,0 +1,162 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/PatriceVignola/rocketpool-go/rocketpool"
+	"github.com/PatriceVignola/rocketpool-go/utils/eth"
+)
+
+// The length of a year used to annualize returns
+const YearDuration = 365 * 24 * time.Hour
+
+// The ETH : rETH exchange rate at a block
+type RETHRateSample struct {
+	Block        uint64   `json:"block"`
+	Time         uint64   `json:"time"`
+	Rate         *big.Int `json:"rate"`
+	ExchangeRate float64  `json:"exchangeRate"`
+}
+
+// The annualized rETH return over a window ending at a sample
+type RETHAPRSample struct {
+	Block      uint64  `json:"block"`
+	Time       uint64  `json:"time"`
+	StartBlock uint64  `json:"startBlock"`
+	StartTime  uint64  `json:"startTime"`
+	APR        float64 `json:"apr"`
+}
+
+// Get the ETH : rETH exchange rate set by each network balances update between fromBlock and toBlock
+// Rates are derived from the BalancesUpdated event data, so no archive state is required
+func GetRETHExchangeRateHistory(rp *rocketpool.RocketPool, fromBlock, toBlock uint64, intervalSize *big.Int) ([]RETHRateSample, error) {
+
+	// Get contracts
+	rocketNetworkBalances, err := rp.GetContract("rocketNetworkBalances")
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the event logs
+	event := rocketNetworkBalances.ABI.Events["BalancesUpdated"]
+	addressFilter := []common.Address{*rocketNetworkBalances.Address}
+	topicFilter := [][]common.Hash{{event.ID}}
+	logs, err := eth.GetLogs(rp, addressFilter, topicFilter, intervalSize, new(big.Int).SetUint64(fromBlock), new(big.Int).SetUint64(toBlock), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the events
+	samples := make([]RETHRateSample, len(logs))
+	for li, log := range logs {
+		values := make(map[string]interface{})
+		if err := event.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return nil, fmt.Errorf("Could not decode BalancesUpdated event: %w", err)
+		}
+		rate := CalculateRETHRate(values["totalEth"].(*big.Int), values["rethSupply"].(*big.Int))
+		samples[li] = RETHRateSample{
+			Block:        log.BlockNumber,
+			Time:         values["time"].(*big.Int).Uint64(),
+			Rate:         rate,
+			ExchangeRate: eth.WeiToEth(rate),
+		}
+	}
+
+	// Return
+	return samples, nil
+
+}
+
+// Get the ETH : rETH exchange rate every blockStep blocks between fromBlock and toBlock
+// Requires historical state for the sampled blocks
+func GetRETHExchangeRateSeries(rp *rocketpool.RocketPool, fromBlock, toBlock, blockStep uint64) ([]RETHRateSample, error) {
+
+	// Check step
+	if blockStep == 0 {
+		return nil, errors.New("rETH exchange rate block step must be at least one block")
+	}
+
+	// Get contracts
+	rocketTokenRETH, err := getRocketTokenRETH(rp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sample rates
+	samples := []RETHRateSample{}
+	for block := fromBlock; block <= toBlock; block += blockStep {
+		header, err := rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(block))
+		if err != nil {
+			return nil, fmt.Errorf("Could not get block %d header: %w", block, err)
+		}
+		rate := new(*big.Int)
+		if err := rocketTokenRETH.Call(&bind.CallOpts{BlockNumber: header.Number}, rate, "getExchangeRate"); err != nil {
+			return nil, fmt.Errorf("Could not get rETH exchange rate at block %d: %w", block, err)
+		}
+		samples = append(samples, RETHRateSample{
+			Block:        block,
+			Time:         header.Time,
+			Rate:         *rate,
+			ExchangeRate: eth.WeiToEth(*rate),
+		})
+		if toBlock-block < blockStep {
+			break
+		}
+	}
+
+	// Return
+	return samples, nil
+
+}
+
+// Calculate the ETH : rETH exchange rate in wei from network balances, matching the rETH contract
+func CalculateRETHRate(totalEth, rethSupply *big.Int) *big.Int {
+	if rethSupply.Sign() == 0 {
+		return eth.EthToWei(1)
+	}
+	rate := new(big.Int).Mul(totalEth, eth.EthToWei(1))
+	return rate.Div(rate, rethSupply)
+}
+
+// Calculate the annualized rETH return at each sample over the preceding window
+// Samples must be sorted by time; samples with less than a full window of history are skipped
+func CalculateRETHAPR(samples []RETHRateSample, window time.Duration) []RETHAPRSample {
+	windowSeconds := uint64(window / time.Second)
+	aprs := []RETHAPRSample{}
+	if windowSeconds == 0 {
+		return aprs
+	}
+	for _, end := range samples {
+		if end.Time < samples[0].Time+windowSeconds {
+			continue
+		}
+
+		// Get the latest sample at or before the window start
+		si := sort.Search(len(samples), func(i int) bool { return samples[i].Time > end.Time-windowSeconds }) - 1
+		start := samples[si]
+		if end.Time <= start.Time || start.Rate.Sign() == 0 {
+			continue
+		}
+
+		// Annualize the rate change over the elapsed time
+		growth := new(big.Float).Quo(new(big.Float).SetInt(end.Rate), new(big.Float).SetInt(start.Rate))
+		growthFloat, _ := growth.Float64()
+		elapsed := float64(end.Time - start.Time)
+		aprs = append(aprs, RETHAPRSample{
+			Block:      end.Block,
+			Time:       end.Time,
+			StartBlock: start.Block,
+			StartTime:  start.Time,
+			APR:        (growthFloat - 1) * YearDuration.Seconds() / elapsed,
+		})
+	}
+	return aprs
+}
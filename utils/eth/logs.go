@@ -47,6 +47,7 @@ func FilterContractLogs(rp *rocketpool.RocketPool, contractName string, q Filter
 }
 
 // Gets the logs for a particular log request, breaking the calls into batches if necessary
+// The interval size and block bounds are not modified
 func GetLogs(rp *rocketpool.RocketPool, addressFilter []common.Address, topicFilter [][]common.Hash, intervalSize, fromBlock, toBlock *big.Int, blockHash *common.Hash) ([]types.Log, error) {
 	var logs []types.Log
 
@@ -85,8 +86,8 @@ func GetLogs(rp *rocketpool.RocketPool, addressFilter []common.Address, topicFil
 		}
 
 		// Set the start and end, clamping on the latest block
-		intervalSize.Sub(intervalSize, big.NewInt(1))
-		start := fromBlock
+		intervalSize = big.NewInt(0).Sub(intervalSize, big.NewInt(1))
+		start := big.NewInt(0).Set(fromBlock)
 		end := big.NewInt(0).Add(start, intervalSize)
 		if end.Cmp(toBlock) == 1 {
 			end = toBlock